@@ -0,0 +1,146 @@
+package graph
+
+import (
+	"math"
+	"slices"
+)
+
+// Shortest path distances between every pair of nodes. Nodes are numbered in the order they were added to the graph,
+// the same order GetNodes and ToAdjacencyEdgeMap walk them in.
+type DistanceMatrix[T any] struct {
+	ig   indexedGraph[T]
+	dist [][]float64
+	pred [][]int // pred[from][to] is the node before to on the path from from, -1 if there is none
+}
+
+func newDistanceMatrix[T any](ig indexedGraph[T]) DistanceMatrix[T] {
+	n := len(ig.nodes)
+	m := DistanceMatrix[T]{ig: ig, dist: make([][]float64, n), pred: make([][]int, n)}
+	for from := range n {
+		m.dist[from] = make([]float64, n)
+		m.pred[from] = make([]int, n)
+		for to := range n {
+			m.dist[from][to] = math.Inf(1)
+			m.pred[from][to] = -1
+		}
+		m.dist[from][from] = 0
+	}
+	return m
+}
+
+// Returns the nodes of the matrix in index order.
+func (m DistanceMatrix[T]) Nodes() []Node[T] {
+	return slices.Clone(m.ig.nodes)
+}
+
+func (m DistanceMatrix[T]) ids(from Node[T], to Node[T]) (int, int, bool) {
+	fromID, okFrom := m.ig.id(from)
+	toID, okTo := m.ig.id(to)
+	return fromID, toID, okFrom && okTo
+}
+
+// Returns the length of the shortest path between both nodes. Reports false if there is no path or either node is not
+// part of the graph.
+func (m DistanceMatrix[T]) Distance(from Node[T], to Node[T]) (float64, bool) {
+	fromID, toID, ok := m.ids(from, to)
+	if !ok || math.IsInf(m.dist[fromID][toID], 1) {
+		return math.Inf(1), false
+	}
+	return m.dist[fromID][toID], true
+}
+
+// Returns the shortest path between both nodes, both ends included. Reports false if there is no path or either node
+// is not part of the graph.
+func (m DistanceMatrix[T]) Path(from Node[T], to Node[T]) ([]Node[T], bool) {
+	fromID, toID, ok := m.ids(from, to)
+	if !ok || math.IsInf(m.dist[fromID][toID], 1) {
+		return nil, false
+	}
+	path := []int{toID}
+	for curr := toID; curr != fromID; {
+		curr = m.pred[fromID][curr]
+		path = append(path, curr)
+	}
+	slices.Reverse(path)
+	return m.ig.toNodes(path), true
+}
+
+// Computes the shortest paths between every pair of nodes with the Floyd-Warshall algorithm. Takes cubic time in the
+// number of nodes regardless of the number of edges, which suits dense graphs. Negative edge weights are allowed, but
+// fails with a NegativeCycleError if the graph has a cycle of negative total weight.
+func (g Graph[T]) FloydWarshall() (DistanceMatrix[T], error) {
+	ig := g.toIndexed()
+	m := newDistanceMatrix(ig)
+	n := len(ig.nodes)
+	for from, edges := range ig.out {
+		for _, edge := range edges {
+			if edge.weight < m.dist[from][edge.to] {
+				m.dist[from][edge.to] = edge.weight
+				m.pred[from][edge.to] = from
+			}
+		}
+	}
+	for via := range n {
+		for from := range n {
+			if math.IsInf(m.dist[from][via], 1) {
+				continue
+			}
+			for to := range n {
+				if newDist := m.dist[from][via] + m.dist[via][to]; newDist < m.dist[from][to] {
+					m.dist[from][to] = newDist
+					m.pred[from][to] = m.pred[via][to]
+				}
+			}
+		}
+	}
+	for node := range n {
+		if m.dist[node][node] < 0 {
+			// The node lies on a negative cycle, Bellman-Ford from it pins down which one.
+			_, cycle := ig.bellmanFord(node)
+			return DistanceMatrix[T]{}, &NegativeCycleError[T]{Cycle: ig.toNodes(cycle)}
+		}
+	}
+	return m, nil
+}
+
+// Computes the shortest paths between every pair of nodes with Johnson's algorithm. Bellman-Ford from a virtual source
+// finds a potential for every node, which reweights the edges so none is negative, and Dijkstras then runs from every
+// node. Suits sparse graphs far better than FloydWarshall. Fails like FloydWarshall.
+func (g Graph[T]) Johnson() (DistanceMatrix[T], error) {
+	ig := g.toIndexed()
+	n := len(ig.nodes)
+
+	// A virtual source with a free edge to every node.
+	augmented := ig
+	augmented.nodes = append(slices.Clip(ig.nodes), nil)
+	augmented.out = append(slices.Clip(ig.out), make([]indexedEdge, 0, n))
+	for node := range n {
+		augmented.out[n] = append(augmented.out[n], indexedEdge{to: node})
+	}
+	potentials, cycle := augmented.bellmanFord(n)
+	if cycle != nil {
+		return DistanceMatrix[T]{}, &NegativeCycleError[T]{Cycle: ig.toNodes(cycle)}
+	}
+
+	reweighted := ig
+	reweighted.out = make([][]indexedEdge, n)
+	for from, edges := range ig.out {
+		for _, edge := range edges {
+			// Never negative in theory, rounding is clamped away.
+			weight := max(0, edge.weight+potentials.dist[from]-potentials.dist[edge.to])
+			reweighted.out[from] = append(reweighted.out[from], indexedEdge{edge.to, weight})
+		}
+	}
+
+	m := newDistanceMatrix(ig)
+	for from := range n {
+		tree := reweighted.dijkstras(from, -1, nil)
+		for to := range n {
+			if tree.settled[to] {
+				m.dist[from][to] = tree.dist[to] - potentials.dist[from] + potentials.dist[to]
+				m.pred[from][to] = tree.pred[to]
+			}
+		}
+	}
+	return m, nil
+}
@@ -0,0 +1,76 @@
+package graph_test
+
+import (
+	"graph"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var allPairsAlgorithms = map[string]func(graph.Graph[int]) (graph.DistanceMatrix[int], error){
+	"FloydWarshall": graph.Graph[int].FloydWarshall,
+	"Johnson":       graph.Graph[int].Johnson,
+}
+
+func TestAllPairsShortestPaths(t *testing.T) {
+	for name, run := range allPairsAlgorithms {
+		t.Run(name, func(t *testing.T) {
+			matrix, err := run(credits())
+			assert.NoError(t, err)
+			assert.Equal(t, credits().GetNodes(), matrix.Nodes())
+
+			dist, ok := matrix.Distance(NumberNode{1}, NumberNode{4})
+			assert.True(t, ok)
+			assert.Equal(t, 3.0, dist)
+			path, ok := matrix.Path(NumberNode{1}, NumberNode{4})
+			assert.True(t, ok)
+			assert.Equal(t, []graph.Node[int]{NumberNode{1}, NumberNode{2}, NumberNode{3}, NumberNode{4}}, path)
+			dist, _ = matrix.Distance(NumberNode{2}, NumberNode{4})
+			assert.Equal(t, -1.0, dist)
+			path, _ = matrix.Path(NumberNode{3}, NumberNode{3})
+			assert.Equal(t, []graph.Node[int]{NumberNode{3}}, path)
+
+			_, ok = matrix.Distance(NumberNode{4}, NumberNode{1})
+			assert.False(t, ok)
+			_, ok = matrix.Path(NumberNode{1}, NumberNode{42})
+			assert.False(t, ok)
+
+			_, err = run(credits().AddEdge(NumberNode{4}, NumberNode{2}, 0))
+			assert.ErrorIs(t, err, graph.ErrNegativeCycle)
+		})
+	}
+}
+
+func TestAllPairsMatchesBellmanFord(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	// Weights shifted by a potential per node can be negative, but every cycle keeps a non negative total.
+	potential := make([]int, 15)
+	for node := range potential {
+		potential[node] = random.Intn(10)
+	}
+	g := graph.CreateDirected[int]()
+	for range 60 {
+		u, v := random.Intn(15), random.Intn(15)
+		g = g.AddEdge(NumberNode{u}, NumberNode{v}, float64(random.Intn(5)+potential[u]-potential[v]))
+	}
+
+	for name, run := range allPairsAlgorithms {
+		t.Run(name, func(t *testing.T) {
+			matrix, err := run(g)
+			assert.NoError(t, err)
+			for _, from := range g.GetNodes() {
+				tree, err := g.BellmanFord(from)
+				assert.NoError(t, err)
+				for _, to := range g.GetNodes() {
+					expected, reachable := tree.DistanceTo(to)
+					actual, ok := matrix.Distance(from, to)
+					assert.Equal(t, reachable, ok)
+					if reachable {
+						assert.InDelta(t, expected, actual, 1e-9)
+					}
+				}
+			}
+		})
+	}
+}
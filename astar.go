@@ -0,0 +1,68 @@
+package graph
+
+import "math"
+
+// Finds a shortest path from the source to the target with A*, guided by the heuristic. The heuristic estimates the
+// remaining cost from a node to the target and must never overestimate it for the result to be optimal. Returns the
+// path with both ends included and its total weight. Fails with a NodeNotFoundError if either node is not part of this
+// graph, with a NegativeWeightError if any edge weight is negative and with ErrNoPath if the target cannot be reached.
+func (g Graph[T]) AStar(source Node[T], target Node[T], heuristic func(Node[T]) float64) ([]Node[T], float64, error) {
+	return g.aStar(source, target, heuristic, false)
+}
+
+// Like AStar, but also verifies that the heuristic is consistent: it must estimate 0 at the target and may not drop by
+// more than the weight of any edge it is evaluated across. Fails with an InconsistentHeuristicError as soon as a
+// violation is found. Meant for tests and debugging, the checks cost an extra heuristic lookup per edge.
+func (g Graph[T]) AStarDebug(source Node[T], target Node[T], heuristic func(Node[T]) float64) ([]Node[T], float64, error) {
+	return g.aStar(source, target, heuristic, true)
+}
+
+func (g Graph[T]) aStar(source Node[T], target Node[T], heuristic func(Node[T]) float64, debug bool) ([]Node[T], float64, error) {
+	ig := g.toIndexed()
+	ids, err := g.prepareDijkstras(ig, source, target)
+	if err != nil {
+		return nil, math.Inf(1), err
+	}
+	from, to := ids[0], ids[1]
+	// Every node is estimated at most once.
+	estimates := make([]float64, len(ig.nodes))
+	estimated := make([]bool, len(ig.nodes))
+	estimate := func(node int) float64 {
+		if !estimated[node] {
+			estimates[node] = heuristic(ig.nodes[node])
+			estimated[node] = true
+		}
+		return estimates[node]
+	}
+	if debug && estimate(to) != 0 {
+		return nil, math.Inf(1), &InconsistentHeuristicError[T]{}
+	}
+
+	tree := newShortestPathTree(ig, from)
+	queue := &priorityQueue{}
+	queue.push(from, estimate(from))
+	for queue.Len() > 0 {
+		item := queue.pop()
+		curr := item.node
+		// Entries are never removed, so skip the ones whose distance has improved since.
+		if item.priority > tree.dist[curr]+estimate(curr) {
+			continue
+		}
+		if curr == to {
+			return ig.toNodes(tree.pathTo(to)), tree.dist[to], nil
+		}
+		for _, edge := range ig.out[curr] {
+			if debug && estimate(curr) > edge.weight+estimate(edge.to)+1e-9 {
+				violating := Edge[T]{u: ig.nodes[curr], v: ig.nodes[edge.to], weight: edge.weight}
+				return nil, math.Inf(1), &InconsistentHeuristicError[T]{Edge: violating}
+			}
+			// Nodes may be reopened, which keeps the result optimal for heuristics that are admissible but not consistent.
+			if newDist := tree.dist[curr] + edge.weight; newDist < tree.dist[edge.to] {
+				tree.dist[edge.to] = newDist
+				tree.pred[edge.to] = curr
+				queue.push(edge.to, newDist+estimate(edge.to))
+			}
+		}
+	}
+	return nil, math.Inf(1), ErrNoPath
+}
@@ -0,0 +1,107 @@
+package graph_test
+
+import (
+	"errors"
+	"graph"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type CellNode struct {
+	x, y int
+}
+
+func (n CellNode) Compare(node graph.Node[[2]int]) int {
+	other := node.Val()
+	if n.x != other[0] {
+		return n.x - other[0]
+	}
+	return n.y - other[1]
+}
+
+func (n CellNode) Equal(node graph.Node[[2]int]) bool {
+	return n.Val() == node.Val()
+}
+
+func (n CellNode) Hash() int {
+	return n.x*1000 + n.y
+}
+
+func (n CellNode) Val() [2]int {
+	return [2]int{n.x, n.y}
+}
+
+// An undirected size x size grid. Column 2 is a wall of expensive cells with a single cheap gap in the last row.
+func grid(size int) graph.Graph[[2]int] {
+	g := graph.CreateUndirected[[2]int]()
+	cost := func(x, y int) float64 {
+		if x == 2 && y < size-1 {
+			return 10
+		}
+		return 1
+	}
+	for x := range size {
+		for y := range size {
+			if x+1 < size {
+				g = g.AddEdge(CellNode{x, y}, CellNode{x + 1, y}, cost(x+1, y))
+			}
+			if y+1 < size {
+				g = g.AddEdge(CellNode{x, y}, CellNode{x, y + 1}, cost(x, y+1))
+			}
+		}
+	}
+	return g
+}
+
+func manhattan(target CellNode) func(graph.Node[[2]int]) float64 {
+	return func(node graph.Node[[2]int]) float64 {
+		cell := node.Val()
+		return math.Abs(float64(cell[0]-target.x)) + math.Abs(float64(cell[1]-target.y))
+	}
+}
+
+func TestAStar(t *testing.T) {
+	g := grid(5)
+	source, target := CellNode{0, 0}, CellNode{4, 0}
+	path, cost, err := g.AStarDebug(source, target, manhattan(target))
+	assert.NoError(t, err)
+
+	tree, _ := g.DijkstrasTree(source)
+	expected, _ := tree.DistanceTo(target)
+	assert.Equal(t, expected, cost)
+	// The cheapest route walks around the wall rather than through it.
+	assert.Equal(t, 12.0, cost)
+	assert.Equal(t, graph.Node[[2]int](source), path[0])
+	assert.Equal(t, graph.Node[[2]int](target), path[len(path)-1])
+
+	// Without a heuristic A* is just Dijkstras.
+	_, cost, err = g.AStar(source, target, func(graph.Node[[2]int]) float64 { return 0 })
+	assert.NoError(t, err)
+	assert.Equal(t, 12.0, cost)
+}
+
+func TestAStarErrors(t *testing.T) {
+	g := grid(3).AddNode(CellNode{9, 9})
+	target := CellNode{2, 2}
+	_, cost, err := g.AStar(CellNode{0, 0}, CellNode{9, 9}, manhattan(CellNode{9, 9}))
+	assert.ErrorIs(t, err, graph.ErrNoPath)
+	assert.True(t, math.IsInf(cost, 1))
+
+	_, _, err = g.AStar(CellNode{0, 0}, CellNode{7, 7}, manhattan(target))
+	assert.ErrorIs(t, err, graph.ErrNodeNotFound)
+
+	// Overestimating the remaining cost is caught in debug mode only.
+	inflated := func(node graph.Node[[2]int]) float64 { return 5 * manhattan(target)(node) }
+	_, _, err = g.AStar(CellNode{0, 0}, target, inflated)
+	assert.NoError(t, err)
+	_, _, err = g.AStarDebug(CellNode{0, 0}, target, inflated)
+	assert.ErrorIs(t, err, graph.ErrInconsistentHeuristic)
+	var inconsistent *graph.InconsistentHeuristicError[[2]int]
+	assert.True(t, errors.As(err, &inconsistent))
+	assert.Equal(t, graph.Node[[2]int](CellNode{0, 0}), inconsistent.Edge.U())
+
+	_, _, err = g.AStarDebug(CellNode{0, 0}, target, func(graph.Node[[2]int]) float64 { return 1 })
+	assert.EqualError(t, err, "heuristic is not consistent: estimate at the target is not 0")
+}
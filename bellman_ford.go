@@ -0,0 +1,131 @@
+package graph
+
+import (
+	"math"
+	"slices"
+)
+
+// Computes the shortest path from the root to every reachable node with the Bellman-Ford algorithm. Unlike Dijkstras
+// negative edge weights are allowed. Rounds stop early once nothing changes. Fails with a NodeNotFoundError if the
+// root is not part of this graph and with a NegativeCycleError if a cycle of negative total weight can be reached from
+// the root. A negative undirected edge counts as such a cycle since it can be walked back and forth.
+func (g Graph[T]) BellmanFord(root Node[T]) (ShortestPathTree[T], error) {
+	ig := g.toIndexed()
+	id, ok := ig.id(root)
+	if !ok {
+		return ShortestPathTree[T]{}, &NodeNotFoundError[T]{Node: root}
+	}
+	tree, cycle := ig.bellmanFord(id)
+	if cycle != nil {
+		return ShortestPathTree[T]{}, &NegativeCycleError[T]{Cycle: ig.toNodes(cycle)}
+	}
+	return tree, nil
+}
+
+// Like BellmanFord, but uses the queue based Shortest Path Faster Algorithm. Only nodes whose distance just improved
+// are revisited, which on sparse graphs is usually much faster than relaxing every edge in every round. Fails like
+// BellmanFord.
+func (g Graph[T]) SPFA(root Node[T]) (ShortestPathTree[T], error) {
+	ig := g.toIndexed()
+	id, ok := ig.id(root)
+	if !ok {
+		return ShortestPathTree[T]{}, &NodeNotFoundError[T]{Node: root}
+	}
+	tree, ok := ig.spfa(id)
+	if !ok {
+		// Run the full algorithm to pin down the offending cycle.
+		_, cycle := ig.bellmanFord(id)
+		return ShortestPathTree[T]{}, &NegativeCycleError[T]{Cycle: ig.toNodes(cycle)}
+	}
+	return tree, nil
+}
+
+// Marks every node with a finite distance as settled so the tree reports it.
+func (t ShortestPathTree[T]) settleReachable() ShortestPathTree[T] {
+	for node, dist := range t.dist {
+		t.settled[node] = !math.IsInf(dist, 1)
+	}
+	return t
+}
+
+// Relaxes every edge up to n-1 times. Returns the nodes of a negative cycle reachable from the root instead of a tree
+// if there is one.
+func (ig indexedGraph[T]) bellmanFord(root int) (ShortestPathTree[T], []int) {
+	tree := newShortestPathTree(ig, root)
+	n := len(ig.nodes)
+	relax := func() int {
+		relaxed := -1
+		for from := range n {
+			if math.IsInf(tree.dist[from], 1) {
+				continue
+			}
+			for _, edge := range ig.out[from] {
+				if newDist := tree.dist[from] + edge.weight; newDist < tree.dist[edge.to] {
+					tree.dist[edge.to] = newDist
+					tree.pred[edge.to] = from
+					relaxed = edge.to
+				}
+			}
+		}
+		return relaxed
+	}
+	for range n - 1 {
+		if relax() == -1 {
+			return tree.settleReachable(), nil
+		}
+	}
+	relaxed := relax()
+	if relaxed == -1 {
+		return tree.settleReachable(), nil
+	}
+	return tree, tree.predecessorCycle(relaxed)
+}
+
+// Finds the cycle in the predecessor pointers that the node leads into, starting from its earliest added node. Walking
+// back n steps from a node that was still relaxed in round n is guaranteed to end up on a negative cycle.
+func (t ShortestPathTree[T]) predecessorCycle(node int) []int {
+	for range len(t.dist) {
+		node = t.pred[node]
+	}
+	cycle := []int{node}
+	for curr := t.pred[node]; curr != node; curr = t.pred[curr] {
+		cycle = append(cycle, curr)
+	}
+	// The predecessors were collected backwards, so flip them into edge order and start from the earliest added node.
+	slices.Reverse(cycle)
+	first := slices.Index(cycle, slices.Min(cycle))
+	return append(cycle[first:], cycle[:first]...)
+}
+
+// Runs the queue based variant of Bellman-Ford. Reports false if a negative cycle can be reached, which is detected
+// once some shortest path would need n or more edges.
+func (ig indexedGraph[T]) spfa(root int) (ShortestPathTree[T], bool) {
+	tree := newShortestPathTree(ig, root)
+	n := len(ig.nodes)
+	edgesOnPath := make([]int, n)
+	inQueue := make([]bool, n)
+	queue := []int{root}
+	inQueue[root] = true
+	for len(queue) > 0 {
+		from := queue[0]
+		queue = queue[1:]
+		inQueue[from] = false
+		for _, edge := range ig.out[from] {
+			newDist := tree.dist[from] + edge.weight
+			if newDist >= tree.dist[edge.to] {
+				continue
+			}
+			tree.dist[edge.to] = newDist
+			tree.pred[edge.to] = from
+			edgesOnPath[edge.to] = edgesOnPath[from] + 1
+			if edgesOnPath[edge.to] >= n {
+				return tree, false
+			}
+			if !inQueue[edge.to] {
+				inQueue[edge.to] = true
+				queue = append(queue, edge.to)
+			}
+		}
+	}
+	return tree.settleReachable(), true
+}
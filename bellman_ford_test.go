@@ -0,0 +1,71 @@
+package graph_test
+
+import (
+	"errors"
+	"graph"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+/*
+1 ──4──► 2 ──-3──► 3 ──2──► 4
+│                  ▲
+└────────2─────────┘
+*/
+func credits() graph.Graph[int] {
+	return graph.CreateDirected[int]().
+		AddEdge(NumberNode{1}, NumberNode{2}, 4).
+		AddEdge(NumberNode{2}, NumberNode{3}, -3).
+		AddEdge(NumberNode{1}, NumberNode{3}, 2).
+		AddEdge(NumberNode{3}, NumberNode{4}, 2).
+		AddNode(NumberNode{5})
+}
+
+func TestBellmanFord(t *testing.T) {
+	for name, run := range map[string]func(graph.Graph[int], graph.Node[int]) (graph.ShortestPathTree[int], error){
+		"BellmanFord": graph.Graph[int].BellmanFord,
+		"SPFA":        graph.Graph[int].SPFA,
+	} {
+		t.Run(name, func(t *testing.T) {
+			tree, err := run(credits(), NumberNode{1})
+			assert.NoError(t, err)
+			dist, _ := tree.DistanceTo(NumberNode{4})
+			assert.Equal(t, 3.0, dist)
+			path, _ := tree.PathTo(NumberNode{4})
+			assert.Equal(t, []graph.Node[int]{NumberNode{1}, NumberNode{2}, NumberNode{3}, NumberNode{4}}, path)
+			assert.False(t, tree.IsReachable(NumberNode{5}))
+
+			// A negative cycle that cannot be reached from the root does not matter.
+			unreachable := credits().AddEdge(NumberNode{5}, NumberNode{6}, -1).AddEdge(NumberNode{6}, NumberNode{5}, -1)
+			_, err = run(unreachable, NumberNode{1})
+			assert.NoError(t, err)
+
+			cyclic := credits().AddEdge(NumberNode{4}, NumberNode{2}, 0)
+			_, err = run(cyclic, NumberNode{1})
+			assert.ErrorIs(t, err, graph.ErrNegativeCycle)
+			var negative *graph.NegativeCycleError[int]
+			assert.True(t, errors.As(err, &negative))
+			assert.Equal(t, []graph.Node[int]{NumberNode{2}, NumberNode{3}, NumberNode{4}}, negative.Cycle)
+
+			_, err = run(credits(), NumberNode{42})
+			assert.ErrorIs(t, err, graph.ErrNodeNotFound)
+		})
+	}
+}
+
+func TestBellmanFordUndirected(t *testing.T) {
+	g := graph.CreateUndirected[int]().
+		AddEdge(NumberNode{1}, NumberNode{2}, 1).
+		AddEdge(NumberNode{2}, NumberNode{3}, 2)
+	tree, err := g.BellmanFord(NumberNode{3})
+	assert.NoError(t, err)
+	dist, _ := tree.DistanceTo(NumberNode{1})
+	assert.Equal(t, 3.0, dist)
+
+	// A negative undirected edge can be walked back and forth forever.
+	_, err = g.AddEdge(NumberNode{3}, NumberNode{4}, -1).SPFA(NumberNode{1})
+	var negative *graph.NegativeCycleError[int]
+	assert.True(t, errors.As(err, &negative))
+	assert.ElementsMatch(t, []graph.Node[int]{NumberNode{3}, NumberNode{4}}, negative.Cycle)
+}
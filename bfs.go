@@ -0,0 +1,174 @@
+package graph
+
+import "slices"
+
+// The result of a breadth first search from a single root, see BFSTree.
+type BreadthFirstTree[T any] struct {
+	ig     indexedGraph[T]
+	root   int
+	dist   []int // the number of edges on a shortest path from the root, -1 for nodes that were not reached
+	parent []int // -1 for the root and nodes that were not reached
+	order  []int
+}
+
+// Runs a breadth first search from the source, taking nodes from the front of a FIFO queue and visiting the neighbors
+// of every node in the order of the graph comparator. Calls discover for every node the first time it is reached,
+// together with the position of the node it was reached from in the discovery order, -1 for the source. Stops as soon
+// as discover returns false. Shared by BFS, BFSSeq, BFSTree and ShortestUnweightedPath.
+func (g Graph[T]) bfs(source Node[T], discover func(node Node[T], parent int) bool) {
+	if !discover(source, -1) {
+		return
+	}
+	visited := newNodeSet[T]()
+	visited.add(source)
+	// The order doubles as the queue, everything after next has been discovered but not expanded yet.
+	order := []Node[T]{source}
+	for next := 0; next < len(order); next++ {
+		edges := g.FindEdgesThatLeadFrom(order[next])
+		slices.SortStableFunc(edges, func(a, b Edge[T]) int {
+			return a.v.Compare(b.v)
+		})
+		for _, edge := range edges {
+			if visited.has(edge.v) {
+				continue
+			}
+			visited.add(edge.v)
+			if !discover(edge.v, next) {
+				return
+			}
+			order = append(order, edge.v)
+		}
+	}
+}
+
+// Records the breadth first search from the root in a tree. Stops as soon as the target is discovered, pass -1 to
+// search everything reachable.
+func (g Graph[T]) bfsTree(ig indexedGraph[T], root int, target int) BreadthFirstTree[T] {
+	tree := BreadthFirstTree[T]{
+		ig:     ig,
+		root:   root,
+		dist:   make([]int, len(ig.nodes)),
+		parent: make([]int, len(ig.nodes)),
+		order:  []int{},
+	}
+	for node := range tree.dist {
+		tree.dist[node], tree.parent[node] = -1, -1
+	}
+	g.bfs(ig.nodes[root], func(node Node[T], parent int) bool {
+		id, _ := ig.id(node)
+		tree.dist[id] = 0
+		if parent != -1 {
+			tree.parent[id] = tree.order[parent]
+			tree.dist[id] = tree.dist[tree.parent[id]] + 1
+		}
+		tree.order = append(tree.order, id)
+		return id != target
+	})
+	return tree
+}
+
+// Runs a breadth first search from the source over everything it can reach, recording the number of edges on a
+// shortest path to every node and the node it was discovered from. Fails with a NodeNotFoundError if the source is not
+// part of this graph.
+func (g Graph[T]) BFSTree(source Node[T]) (BreadthFirstTree[T], error) {
+	ig := g.toIndexed()
+	root, ok := ig.id(source)
+	if !ok {
+		return BreadthFirstTree[T]{}, &NodeNotFoundError[T]{Node: source}
+	}
+	return g.bfsTree(ig, root, -1), nil
+}
+
+// Finds a path with the fewest edges from the source to the target, ignoring edge weights. The breadth first search
+// stops as soon as it discovers the target. The weight of the returned path is its number of edges. Fails with a
+// NodeNotFoundError if either node is not part of this graph and with ErrNoPath if the target cannot be reached.
+func (g Graph[T]) ShortestUnweightedPath(source Node[T], target Node[T]) (Path[T], error) {
+	ig := g.toIndexed()
+	ids := make([]int, 0, 2)
+	for _, node := range []Node[T]{source, target} {
+		id, ok := ig.id(node)
+		if !ok {
+			return Path[T]{}, &NodeNotFoundError[T]{Node: node}
+		}
+		ids = append(ids, id)
+	}
+	tree := g.bfsTree(ig, ids[0], ids[1])
+	if tree.dist[ids[1]] == -1 {
+		return Path[T]{}, ErrNoPath
+	}
+	return Path[T]{Nodes: ig.toNodes(tree.pathTo(ids[1])), Weight: float64(tree.dist[ids[1]])}, nil
+}
+
+func (t BreadthFirstTree[T]) reached(node Node[T]) (int, bool) {
+	id, ok := t.ig.id(node)
+	if !ok || t.dist[id] == -1 {
+		return -1, false
+	}
+	return id, true
+}
+
+func (t BreadthFirstTree[T]) Root() Node[T] {
+	return t.ig.nodes[t.root]
+}
+
+// Checks if the search reached the given node.
+func (t BreadthFirstTree[T]) IsReachable(node Node[T]) bool {
+	_, ok := t.reached(node)
+	return ok
+}
+
+// Returns the number of edges on a shortest path from the root to the node. Reports false if the node was not reached.
+func (t BreadthFirstTree[T]) DistanceTo(node Node[T]) (int, bool) {
+	id, ok := t.reached(node)
+	if !ok {
+		return -1, false
+	}
+	return t.dist[id], true
+}
+
+// Returns the node the search discovered the given node from. Reports false for the root and nodes that were not
+// reached.
+func (t BreadthFirstTree[T]) Parent(node Node[T]) (Node[T], bool) {
+	id, ok := t.reached(node)
+	if !ok || t.parent[id] == -1 {
+		return nil, false
+	}
+	return t.ig.nodes[t.parent[id]], true
+}
+
+// Returns a path with the fewest edges from the root to the node, both ends included. Reports false if the node was
+// not reached.
+func (t BreadthFirstTree[T]) PathTo(node Node[T]) ([]Node[T], bool) {
+	id, ok := t.reached(node)
+	if !ok {
+		return nil, false
+	}
+	return t.ig.toNodes(t.pathTo(id)), true
+}
+
+func (t BreadthFirstTree[T]) pathTo(id int) []int {
+	path := []int{}
+	for curr := id; curr != -1; curr = t.parent[curr] {
+		path = append(path, curr)
+	}
+	slices.Reverse(path)
+	return path
+}
+
+// Groups the reached nodes by their distance from the root. Level 0 only holds the root, and every level keeps the
+// order the nodes were discovered in.
+func (t BreadthFirstTree[T]) Levels() [][]Node[T] {
+	levels := [][]Node[T]{}
+	for _, node := range t.order {
+		if t.dist[node] == len(levels) {
+			levels = append(levels, []Node[T]{})
+		}
+		levels[t.dist[node]] = append(levels[t.dist[node]], t.ig.nodes[node])
+	}
+	return levels
+}
+
+// Returns the reached nodes in the order they were discovered.
+func (t BreadthFirstTree[T]) Order() []Node[T] {
+	return t.ig.toNodes(t.order)
+}
@@ -0,0 +1,73 @@
+package graph_test
+
+import (
+	"graph"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBFSIsBreadthFirst(t *testing.T) {
+	// DFS dives into 2 before looking at 3, BFS visits both before going deeper.
+	g := forestGraph()
+	assert.Equal(t,
+		[]graph.Node[int]{NumberNode{1}, NumberNode{2}, NumberNode{3}, NumberNode{4}, NumberNode{5}},
+		g.DFS(NumberNode{1}))
+	assert.Equal(t,
+		[]graph.Node[int]{NumberNode{1}, NumberNode{2}, NumberNode{4}, NumberNode{5}, NumberNode{3}},
+		g.BFS(NumberNode{1}))
+	assert.Equal(t, []graph.Node[int]{NumberNode{42}}, g.BFS(NumberNode{42}))
+}
+
+func TestBFSTree(t *testing.T) {
+	tree, err := forestGraph().BFSTree(NumberNode{1})
+	assert.NoError(t, err)
+	assert.Equal(t, graph.Node[int](NumberNode{1}), tree.Root())
+	assert.Equal(t, [][]graph.Node[int]{
+		{NumberNode{1}},
+		{NumberNode{2}, NumberNode{4}, NumberNode{5}},
+		{NumberNode{3}},
+	}, tree.Levels())
+
+	dist, ok := tree.DistanceTo(NumberNode{5})
+	assert.True(t, ok)
+	assert.Equal(t, 1, dist)
+	parent, ok := tree.Parent(NumberNode{3})
+	assert.True(t, ok)
+	assert.Equal(t, graph.Node[int](NumberNode{2}), parent)
+	_, ok = tree.Parent(NumberNode{1})
+	assert.False(t, ok)
+	path, ok := tree.PathTo(NumberNode{3})
+	assert.True(t, ok)
+	assert.Equal(t, []graph.Node[int]{NumberNode{1}, NumberNode{2}, NumberNode{3}}, path)
+
+	assert.False(t, tree.IsReachable(NumberNode{6}))
+	_, ok = tree.DistanceTo(NumberNode{6})
+	assert.False(t, ok)
+	_, ok = tree.PathTo(NumberNode{6})
+	assert.False(t, ok)
+
+	_, err = forestGraph().BFSTree(NumberNode{42})
+	assert.ErrorIs(t, err, graph.ErrNodeNotFound)
+}
+
+func TestShortestUnweightedPath(t *testing.T) {
+	// Weights are ignored, so 1 -> 2 -> 4 with two edges beats the lighter detour 1 -> 3 -> 2 -> 4 with three.
+	path, err := detour().ShortestUnweightedPath(NumberNode{1}, NumberNode{4})
+	assert.NoError(t, err)
+	assert.Equal(t, []graph.Node[int]{NumberNode{1}, NumberNode{2}, NumberNode{4}}, path.Nodes)
+	assert.Equal(t, 2.0, path.Weight)
+
+	path, err = detour().ShortestUnweightedPath(NumberNode{3}, NumberNode{3})
+	assert.NoError(t, err)
+	assert.Equal(t, []graph.Node[int]{NumberNode{3}}, path.Nodes)
+
+	cells, err := grid(6).ShortestUnweightedPath(CellNode{0, 0}, CellNode{5, 5})
+	assert.NoError(t, err)
+	assert.Equal(t, 10.0, cells.Weight)
+
+	_, err = detour().ShortestUnweightedPath(NumberNode{4}, NumberNode{1})
+	assert.ErrorIs(t, err, graph.ErrNoPath)
+	_, err = detour().ShortestUnweightedPath(NumberNode{1}, NumberNode{42})
+	assert.ErrorIs(t, err, graph.ErrNodeNotFound)
+}
@@ -0,0 +1,176 @@
+package graph
+
+import "slices"
+
+// The articulation points, bridges and biconnected components of an undirected graph. Edges are referred to by their
+// position in GetEdges.
+type biconnectivity struct {
+	articulation []bool
+	bridges      []int
+	components   [][]int // the nodes of every biconnected component, ascending
+}
+
+// Computes everything biconnectivity holds with a single iterative Tarjan DFS. Every edge leads both ways, but the DFS
+// never walks back over the very edge it came from, so a parallel edge still counts as a second connection. Self loops
+// are ignored.
+func (g Graph[T]) biconnectivity() (indexedGraph[T], biconnectivity, error) {
+	if g.directed {
+		return indexedGraph[T]{}, biconnectivity{}, ErrDirectedGraph
+	}
+	ig := g.toIndexed()
+	type incidence struct {
+		to   int
+		edge int
+	}
+	incident := make([][]incidence, len(ig.nodes))
+	ends := [][2]int{}
+	for id, edge := range g.GetEdges() {
+		u, _ := ig.id(edge.u)
+		v, _ := ig.id(edge.v)
+		ends = append(ends, [2]int{u, v})
+		if u != v {
+			incident[u] = append(incident[u], incidence{v, id})
+			incident[v] = append(incident[v], incidence{u, id})
+		}
+	}
+
+	result := biconnectivity{articulation: make([]bool, len(ig.nodes)), bridges: []int{}, components: [][]int{}}
+	discovered := make([]int, len(ig.nodes))
+	low := make([]int, len(ig.nodes))
+	for node := range discovered {
+		discovered[node] = -1
+	}
+	time := 0
+	discover := func(node int) {
+		discovered[node], low[node] = time, time
+		time++
+	}
+	// The edges of the components that are still being explored, the top ones belong to the innermost component.
+	edgeStack := []int{}
+	type frame struct {
+		node int
+		via  int // the edge the DFS arrived over, -1 for the root
+		next int
+	}
+	for root := range ig.nodes {
+		if discovered[root] != -1 {
+			continue
+		}
+		discover(root)
+		children := 0
+		callStack := []frame{{node: root, via: -1}}
+		for len(callStack) > 0 {
+			top := &callStack[len(callStack)-1]
+			if top.next < len(incident[top.node]) {
+				next := incident[top.node][top.next]
+				top.next++
+				switch {
+				case next.edge == top.via:
+					// Going straight back to the parent is not a second connection.
+				case discovered[next.to] == -1:
+					if top.node == root {
+						children++
+					}
+					edgeStack = append(edgeStack, next.edge)
+					discover(next.to)
+					callStack = append(callStack, frame{node: next.to, via: next.edge})
+				case discovered[next.to] < discovered[top.node]:
+					// A back edge to an ancestor. The ancestor runs into the same edge later, where it leads to a
+					// descendant and is ignored.
+					low[top.node] = min(low[top.node], discovered[next.to])
+					edgeStack = append(edgeStack, next.edge)
+				}
+				continue
+			}
+			child := *top
+			callStack = callStack[:len(callStack)-1]
+			if len(callStack) == 0 {
+				break
+			}
+			parent := callStack[len(callStack)-1].node
+			low[parent] = min(low[parent], low[child.node])
+			if low[child.node] > discovered[parent] {
+				result.bridges = append(result.bridges, child.via)
+			}
+			if low[child.node] < discovered[parent] {
+				continue
+			}
+			// Nothing below the child reaches above the parent, so the parent separates them.
+			if parent != root {
+				result.articulation[parent] = true
+			}
+			members := map[int]bool{}
+			for {
+				edge := edgeStack[len(edgeStack)-1]
+				edgeStack = edgeStack[:len(edgeStack)-1]
+				members[ends[edge][0]], members[ends[edge][1]] = true, true
+				if edge == child.via {
+					break
+				}
+			}
+			component := []int{}
+			for member := range members {
+				component = append(component, member)
+			}
+			slices.Sort(component)
+			result.components = append(result.components, component)
+		}
+		if children > 1 {
+			result.articulation[root] = true
+		}
+	}
+	slices.Sort(result.bridges)
+	slices.SortStableFunc(result.components, func(a, b []int) int {
+		return a[0] - b[0]
+	})
+	return ig, result, nil
+}
+
+// Finds the nodes whose removal would split their connected component of this undirected graph, in the order they
+// were added. Fails with ErrDirectedGraph on a directed graph.
+func (g Graph[T]) ArticulationPoints() ([]Node[T], error) {
+	ig, result, err := g.biconnectivity()
+	if err != nil {
+		return nil, err
+	}
+	points := []Node[T]{}
+	for node, isPoint := range result.articulation {
+		if isPoint {
+			points = append(points, ig.nodes[node])
+		}
+	}
+	return points, nil
+}
+
+// Finds the edges whose removal would split their connected component of this undirected graph, in the order they
+// were added. An edge with a parallel edge next to it is never a bridge. Fails with ErrDirectedGraph on a directed
+// graph.
+func (g Graph[T]) Bridges() ([]Edge[T], error) {
+	_, result, err := g.biconnectivity()
+	if err != nil {
+		return nil, err
+	}
+	edges := g.GetEdges()
+	bridges := make([]Edge[T], 0, len(result.bridges))
+	for _, id := range result.bridges {
+		bridges = append(bridges, edges[id])
+	}
+	return bridges, nil
+}
+
+// Computes the biconnected components of this undirected graph, i.e. the maximal groups of nodes that stay connected
+// after removing any single node. Articulation points belong to every component they join, a bridge forms a component
+// of its own and nodes without edges other than self loops belong to none. Components are ordered by their earliest
+// added node and nodes within a component keep the order they were added in. Fails with ErrDirectedGraph on a directed
+// graph.
+func (g Graph[T]) BiconnectedComponents() ([][]Node[T], error) {
+	ig, result, err := g.biconnectivity()
+	if err != nil {
+		return nil, err
+	}
+	components := make([][]Node[T], 0, len(result.components))
+	for _, component := range result.components {
+		components = append(components, ig.toNodes(component))
+	}
+	return components, nil
+}
@@ -0,0 +1,101 @@
+package graph_test
+
+import (
+	"graph"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+/*
+1 ─── 2 ─── 4 ─── 7 ─── 5 ═══ 6
+│    ╱            │
+3 ──╯             8 ⟲
+*/
+func resilience() graph.Graph[int] {
+	return graph.CreateUndirected[int]().
+		AddEdge(NumberNode{1}, NumberNode{2}, 0).
+		AddEdge(NumberNode{2}, NumberNode{3}, 0).
+		AddEdge(NumberNode{3}, NumberNode{1}, 0).
+		AddEdge(NumberNode{2}, NumberNode{4}, 0).
+		AddEdge(NumberNode{4}, NumberNode{7}, 0).
+		AddEdge(NumberNode{7}, NumberNode{5}, 0).
+		AddEdge(NumberNode{5}, NumberNode{6}, 0).
+		AddEdge(NumberNode{6}, NumberNode{5}, 0).
+		AddEdge(NumberNode{7}, NumberNode{8}, 0).
+		AddEdge(NumberNode{8}, NumberNode{8}, 0).
+		AddNode(NumberNode{9})
+}
+
+func TestArticulationPointsAndBridges(t *testing.T) {
+	points, err := resilience().ArticulationPoints()
+	assert.NoError(t, err)
+	assert.Equal(t, []graph.Node[int]{NumberNode{2}, NumberNode{4}, NumberNode{7}, NumberNode{5}}, points)
+
+	// The doubled edge between 5 and 6 and the self loop on 8 do not matter.
+	bridges, err := resilience().Bridges()
+	assert.NoError(t, err)
+	assert.Equal(t, []graph.Edge[int]{
+		graph.CreateEdge[int](NumberNode{2}, NumberNode{4}, 0),
+		graph.CreateEdge[int](NumberNode{4}, NumberNode{7}, 0),
+		graph.CreateEdge[int](NumberNode{7}, NumberNode{5}, 0),
+		graph.CreateEdge[int](NumberNode{7}, NumberNode{8}, 0),
+	}, bridges)
+
+	_, err = detour().ArticulationPoints()
+	assert.ErrorIs(t, err, graph.ErrDirectedGraph)
+	_, err = detour().Bridges()
+	assert.ErrorIs(t, err, graph.ErrDirectedGraph)
+}
+
+func TestBiconnectedComponents(t *testing.T) {
+	components, err := resilience().BiconnectedComponents()
+	assert.NoError(t, err)
+	assert.Equal(t, [][]graph.Node[int]{
+		{NumberNode{1}, NumberNode{2}, NumberNode{3}},
+		{NumberNode{2}, NumberNode{4}},
+		{NumberNode{4}, NumberNode{7}},
+		{NumberNode{7}, NumberNode{5}},
+		{NumberNode{7}, NumberNode{8}},
+		{NumberNode{5}, NumberNode{6}},
+	}, components)
+
+	_, err = detour().BiconnectedComponents()
+	assert.ErrorIs(t, err, graph.ErrDirectedGraph)
+}
+
+// Removing an articulation point or a bridge has to leave more components behind.
+func TestBiconnectivityMatchesBruteForce(t *testing.T) {
+	random := rand.New(rand.NewSource(8))
+	for range 30 {
+		g := graph.CreateUndirected[int]()
+		for node := range 12 {
+			g = g.AddNode(NumberNode{node})
+		}
+		for range 14 {
+			g = g.AddEdge(NumberNode{random.Intn(12)}, NumberNode{random.Intn(12)}, 0)
+		}
+		components := len(g.ConnectedComponents())
+
+		points, err := g.ArticulationPoints()
+		assert.NoError(t, err)
+		expectedPoints := []graph.Node[int]{}
+		for _, node := range g.GetNodes() {
+			if len(g.RemoveNode(node).ConnectedComponents()) > components {
+				expectedPoints = append(expectedPoints, node)
+			}
+		}
+		assert.Equal(t, expectedPoints, points)
+
+		bridges, err := g.Bridges()
+		assert.NoError(t, err)
+		expectedBridges := []graph.Edge[int]{}
+		for _, edge := range g.GetEdges() {
+			if len(g.RemoveEdge(edge.U(), edge.V()).ConnectedComponents()) > components {
+				expectedBridges = append(expectedBridges, edge)
+			}
+		}
+		assert.Equal(t, expectedBridges, bridges)
+	}
+}
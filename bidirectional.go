@@ -0,0 +1,165 @@
+package graph
+
+import (
+	"math"
+	"slices"
+)
+
+// Numbers nodes in the order a bidirectional search discovers them, so the search only ever touches the part of the
+// graph it explores instead of snapshotting all of it up front.
+type discoveredNodes[T any] struct {
+	ids   *nodeMap[T, int]
+	nodes []Node[T]
+}
+
+func (d *discoveredNodes[T]) id(node Node[T]) int {
+	if id, ok := d.ids.get(node); ok {
+		return id
+	}
+	d.ids.put(node, len(d.nodes))
+	d.nodes = append(d.nodes, node)
+	return len(d.nodes) - 1
+}
+
+// One half of a bidirectional search, either growing forwards from the source or backwards from the target.
+type searchSide[T any] struct {
+	dist     map[int]float64
+	pred     map[int]int // -1 for the node the side started from
+	edges    func(Node[T]) []Edge[T]
+	far      func(Edge[T]) Node[T] // the end of an edge that lies further away from where the side started
+	queue    *priorityQueue        // only used by the weighted search
+	settled  map[int]bool          // only used by the weighted search
+	frontier []int                 // only used by the unweighted search
+}
+
+func newSearchSide[T any](start int, edges func(Node[T]) []Edge[T], far func(Edge[T]) Node[T]) *searchSide[T] {
+	queue := &priorityQueue{}
+	queue.push(start, 0)
+	return &searchSide[T]{
+		dist:     map[int]float64{start: 0},
+		pred:     map[int]int{start: -1},
+		edges:    edges,
+		far:      far,
+		queue:    queue,
+		settled:  map[int]bool{},
+		frontier: []int{start},
+	}
+}
+
+// Sets up a search that follows edges away from the source with FindEdgesThatLeadFrom and one that follows them back
+// from the target with FindEdgesThatLeadTo.
+func (g Graph[T]) bidirectionalSearch(source Node[T], target Node[T]) (*discoveredNodes[T], *searchSide[T], *searchSide[T], error) {
+	for _, node := range []Node[T]{source, target} {
+		if !g.ContainsNode(node) {
+			return nil, nil, nil, &NodeNotFoundError[T]{Node: node}
+		}
+	}
+	discovered := &discoveredNodes[T]{ids: newNodeMap[T, int]()}
+	forward := newSearchSide(discovered.id(source), g.FindEdgesThatLeadFrom, func(e Edge[T]) Node[T] { return e.v })
+	backward := newSearchSide(discovered.id(target), g.FindEdgesThatLeadTo, func(e Edge[T]) Node[T] { return e.u })
+	return discovered, forward, backward, nil
+}
+
+// Joins the paths found by both sides at the node where they meet.
+func (d *discoveredNodes[T]) joinAt(meet int, forward *searchSide[T], backward *searchSide[T]) []Node[T] {
+	path := []Node[T]{}
+	for curr := meet; curr != -1; curr = forward.pred[curr] {
+		path = append(path, d.nodes[curr])
+	}
+	slices.Reverse(path)
+	for curr := backward.pred[meet]; curr != -1; curr = backward.pred[curr] {
+		path = append(path, d.nodes[curr])
+	}
+	return path
+}
+
+// Finds a shortest path from the source to the target by running Dijkstras forwards from the source and backwards from
+// the target at the same time until the two searches meet in the middle. On large graphs this settles far fewer nodes
+// than searching from the source alone. Only the edges the search runs into are checked for negative weights. Fails
+// with a NodeNotFoundError if either node is not part of this graph, with a NegativeWeightError for a negative edge and
+// with ErrNoPath if the target cannot be reached.
+func (g Graph[T]) BidirectionalShortestPath(source Node[T], target Node[T]) (Path[T], error) {
+	discovered, forward, backward, err := g.bidirectionalSearch(source, target)
+	if err != nil {
+		return Path[T]{}, err
+	}
+	best, meet := math.Inf(1), -1
+	if source.Equal(target) {
+		best, meet = 0, 0
+	}
+	for forward.queue.Len() > 0 && backward.queue.Len() > 0 {
+		// Any path through a node neither side has settled yet is at least as long as both frontiers together.
+		if (*forward.queue)[0].priority+(*backward.queue)[0].priority >= best {
+			break
+		}
+		side, other := forward, backward
+		if (*backward.queue)[0].priority < (*forward.queue)[0].priority {
+			side, other = backward, forward
+		}
+		item := side.queue.pop()
+		curr := item.node
+		if side.settled[curr] || item.priority > side.dist[curr] {
+			continue
+		}
+		side.settled[curr] = true
+		for _, edge := range side.edges(discovered.nodes[curr]) {
+			if edge.weight < 0 {
+				return Path[T]{}, &NegativeWeightError[T]{Edge: edge}
+			}
+			next := discovered.id(side.far(edge))
+			newDist := side.dist[curr] + edge.weight
+			if dist, ok := side.dist[next]; !ok || newDist < dist {
+				side.dist[next] = newDist
+				side.pred[next] = curr
+				side.queue.push(next, newDist)
+			}
+			if otherDist, ok := other.dist[next]; ok && side.dist[next]+otherDist < best {
+				best, meet = side.dist[next]+otherDist, next
+			}
+		}
+	}
+	if meet == -1 {
+		return Path[T]{}, ErrNoPath
+	}
+	return Path[T]{Nodes: discovered.joinAt(meet, forward, backward), Weight: best}, nil
+}
+
+// Finds a path with the fewest edges from the source to the target, ignoring edge weights. Runs a BFS forwards from the
+// source and backwards from the target, each round expanding a whole level of whichever side has the smaller frontier,
+// and stops at the first level where the two touch. The weight of the returned path is its number of edges. Fails with
+// a NodeNotFoundError if either node is not part of this graph and with ErrNoPath if the target cannot be reached.
+func (g Graph[T]) BidirectionalUnweightedPath(source Node[T], target Node[T]) (Path[T], error) {
+	discovered, forward, backward, err := g.bidirectionalSearch(source, target)
+	if err != nil {
+		return Path[T]{}, err
+	}
+	if source.Equal(target) {
+		return Path[T]{Nodes: []Node[T]{source}}, nil
+	}
+	for len(forward.frontier) > 0 && len(backward.frontier) > 0 {
+		side, other := forward, backward
+		if len(backward.frontier) < len(forward.frontier) {
+			side, other = backward, forward
+		}
+		best, meet := math.Inf(1), -1
+		next := []int{}
+		for _, curr := range side.frontier {
+			for _, edge := range side.edges(discovered.nodes[curr]) {
+				neighbor := discovered.id(side.far(edge))
+				if _, seen := side.dist[neighbor]; !seen {
+					side.dist[neighbor] = side.dist[curr] + 1
+					side.pred[neighbor] = curr
+					next = append(next, neighbor)
+				}
+				if otherDist, ok := other.dist[neighbor]; ok && side.dist[neighbor]+otherDist < best {
+					best, meet = side.dist[neighbor]+otherDist, neighbor
+				}
+			}
+		}
+		if meet != -1 {
+			return Path[T]{Nodes: discovered.joinAt(meet, forward, backward), Weight: best}, nil
+		}
+		side.frontier = next
+	}
+	return Path[T]{}, ErrNoPath
+}
@@ -0,0 +1,81 @@
+package graph_test
+
+import (
+	"graph"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBidirectionalShortestPath(t *testing.T) {
+	path, err := detour().BidirectionalShortestPath(NumberNode{1}, NumberNode{4})
+	assert.NoError(t, err)
+	assert.Equal(t, []graph.Node[int]{NumberNode{1}, NumberNode{3}, NumberNode{2}, NumberNode{4}}, path.Nodes)
+	assert.Equal(t, 3.0, path.Weight)
+
+	path, err = detour().BidirectionalShortestPath(NumberNode{2}, NumberNode{2})
+	assert.NoError(t, err)
+	assert.Equal(t, []graph.Node[int]{NumberNode{2}}, path.Nodes)
+	assert.Equal(t, 0.0, path.Weight)
+
+	// The grid is undirected, so the backward search walks edges that were added leading away from the target.
+	cells, err := grid(6).BidirectionalShortestPath(CellNode{0, 0}, CellNode{5, 0})
+	assert.NoError(t, err)
+	assert.Equal(t, 14.0, cells.Weight)
+
+	_, err = detour().BidirectionalShortestPath(NumberNode{4}, NumberNode{1})
+	assert.ErrorIs(t, err, graph.ErrNoPath)
+	_, err = detour().BidirectionalShortestPath(NumberNode{1}, NumberNode{42})
+	assert.ErrorIs(t, err, graph.ErrNodeNotFound)
+	_, err = credits().BidirectionalShortestPath(NumberNode{2}, NumberNode{4})
+	assert.ErrorIs(t, err, graph.ErrNegativeWeight)
+}
+
+func TestBidirectionalMatchesDijkstras(t *testing.T) {
+	random := rand.New(rand.NewSource(2))
+	for range 20 {
+		g := graph.CreateDirected[int]()
+		for range 40 {
+			g = g.AddEdge(NumberNode{random.Intn(20)}, NumberNode{random.Intn(20)}, float64(random.Intn(10)))
+		}
+		for _, source := range g.GetNodes() {
+			tree, _ := g.DijkstrasTree(source)
+			for _, target := range g.GetNodes() {
+				path, err := g.BidirectionalShortestPath(source, target)
+				dist, ok := tree.DistanceTo(target)
+				if !ok {
+					assert.ErrorIs(t, err, graph.ErrNoPath)
+					continue
+				}
+				assert.NoError(t, err)
+				assert.Equal(t, dist, path.Weight)
+				assert.True(t, path.Nodes[0].Equal(source))
+				assert.True(t, path.Nodes[len(path.Nodes)-1].Equal(target))
+			}
+		}
+	}
+}
+
+func TestBidirectionalUnweightedPath(t *testing.T) {
+	// The direct edge is expensive but has the fewest hops.
+	path, err := detour().BidirectionalUnweightedPath(NumberNode{1}, NumberNode{4})
+	assert.NoError(t, err)
+	assert.Equal(t, []graph.Node[int]{NumberNode{1}, NumberNode{2}, NumberNode{4}}, path.Nodes)
+	assert.Equal(t, 2.0, path.Weight)
+
+	cells, err := grid(6).BidirectionalUnweightedPath(CellNode{0, 0}, CellNode{5, 5})
+	assert.NoError(t, err)
+	assert.Equal(t, 10.0, cells.Weight)
+	assert.Len(t, cells.Nodes, 11)
+
+	// Negative weights do not matter when only hops are counted.
+	path, err = credits().BidirectionalUnweightedPath(NumberNode{2}, NumberNode{4})
+	assert.NoError(t, err)
+	assert.Equal(t, []graph.Node[int]{NumberNode{2}, NumberNode{3}, NumberNode{4}}, path.Nodes)
+
+	_, err = detour().BidirectionalUnweightedPath(NumberNode{1}, NumberNode{5})
+	assert.ErrorIs(t, err, graph.ErrNoPath)
+	_, err = detour().BidirectionalUnweightedPath(NumberNode{42}, NumberNode{1})
+	assert.ErrorIs(t, err, graph.ErrNodeNotFound)
+}
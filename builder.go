@@ -0,0 +1,203 @@
+package graph
+
+import "slices"
+
+// Accumulates nodes and edges in place and hands out an immutable Graph through Freeze. Meant for bulk loading, where
+// the intermediate graph after every single insert is never needed: the builder works on plain slices and the
+// persistent structures behind a Graph are only built once, when it is frozen. Nodes are deduplicated by the same rules
+// as Graph.AddEdge and Graph.AddNode.
+type GraphBuilder[T any] struct {
+	directed bool
+	nodes    []Node[T]
+	ids      *nodeMap[T, int] // position of every node in nodes
+	edges    []Edge[T]
+	removed  []bool  // marks edges dropped by RemoveEdge, their slots are skipped by Freeze
+	out      [][]int // ids of the live edges whose u is the node, ascending
+	in       [][]int // ids of the live edges whose v is the node, ascending
+	live     int
+	frozen   *Graph[T] // the graph handed out by the last Freeze, until the next change
+}
+
+func CreateDirectedBuilder[T any]() *GraphBuilder[T] {
+	return &GraphBuilder[T]{directed: true, ids: newNodeMap[T, int]()}
+}
+
+func CreateUndirectedBuilder[T any]() *GraphBuilder[T] {
+	return &GraphBuilder[T]{directed: false, ids: newNodeMap[T, int]()}
+}
+
+// Creates a builder that starts out with every node and edge of this graph. Changes made through the builder never
+// affect this graph.
+func (g Graph[T]) ToBuilder() *GraphBuilder[T] {
+	b := &GraphBuilder[T]{directed: g.directed, ids: newNodeMap[T, int]()}
+	for _, node := range g.nodes.all() {
+		b.AddNode(node)
+	}
+	for _, edge := range g.edges.all() {
+		b.addEdge(edge)
+	}
+	frozen := g
+	b.frozen = &frozen
+	return b
+}
+
+// Returns the position of the node, registering it first if it is new.
+func (b *GraphBuilder[T]) insertNode(node Node[T]) int {
+	if id, ok := b.ids.get(node); ok {
+		return id
+	}
+	id := len(b.nodes)
+	b.ids.put(node, id)
+	b.nodes = append(b.nodes, node)
+	b.out = append(b.out, nil)
+	b.in = append(b.in, nil)
+	b.frozen = nil
+	return id
+}
+
+func (b *GraphBuilder[T]) addEdge(edge Edge[T]) {
+	u, v := b.insertNode(edge.u), b.insertNode(edge.v)
+	id := len(b.edges)
+	b.edges = append(b.edges, edge)
+	b.removed = append(b.removed, false)
+	b.out[u] = append(b.out[u], id)
+	b.in[v] = append(b.in[v], id)
+	b.live++
+	b.frozen = nil
+}
+
+func (b *GraphBuilder[T]) AddNode(node Node[T]) *GraphBuilder[T] {
+	b.insertNode(node)
+	return b
+}
+
+func (b *GraphBuilder[T]) AddNodes(nodes ...Node[T]) *GraphBuilder[T] {
+	for _, node := range nodes {
+		b.insertNode(node)
+	}
+	return b
+}
+
+func (b *GraphBuilder[T]) AddEdge(u Node[T], v Node[T], weight float64) *GraphBuilder[T] {
+	b.addEdge(Edge[T]{u: u, v: v, weight: weight})
+	return b
+}
+
+func (b *GraphBuilder[T]) AddEdgeWithCost(u Node[T], v Node[T], weight float64, cost float64) *GraphBuilder[T] {
+	b.addEdge(Edge[T]{u: u, v: v, weight: weight, cost: cost})
+	return b
+}
+
+// Adds every edge in order, see CreateEdge.
+func (b *GraphBuilder[T]) AddEdges(edges ...Edge[T]) *GraphBuilder[T] {
+	for _, edge := range edges {
+		b.addEdge(edge)
+	}
+	return b
+}
+
+// Removes the earliest added edge from u to v, if there is one. In an undirected builder the edge may have been added
+// in either orientation.
+func (b *GraphBuilder[T]) RemoveEdge(u Node[T], v Node[T]) *GraphBuilder[T] {
+	from, ok := b.ids.get(u)
+	if !ok {
+		return b
+	}
+	to, ok := b.ids.get(v)
+	if !ok {
+		return b
+	}
+	earliest := -1
+	for _, id := range b.out[from] {
+		if b.edges[id].v.Equal(v) {
+			earliest = id
+			break
+		}
+	}
+	if !b.directed {
+		for _, id := range b.out[to] {
+			if b.edges[id].v.Equal(u) {
+				if earliest == -1 || id < earliest {
+					earliest = id
+				}
+				break
+			}
+		}
+	}
+	if earliest == -1 {
+		return b
+	}
+	edge := b.edges[earliest]
+	start, _ := b.ids.get(edge.u)
+	end, _ := b.ids.get(edge.v)
+	b.out[start] = slices.DeleteFunc(b.out[start], func(id int) bool { return id == earliest })
+	b.in[end] = slices.DeleteFunc(b.in[end], func(id int) bool { return id == earliest })
+	b.removed[earliest] = true
+	b.live--
+	b.frozen = nil
+	return b
+}
+
+func (b *GraphBuilder[T]) GetNumberOfNodes() int {
+	return len(b.nodes)
+}
+
+func (b *GraphBuilder[T]) GetNumberOfEdges() int {
+	return b.live
+}
+
+// Returns the graph built so far, building its persistent structures in a single pass. The builder may keep being used
+// afterwards, later changes are not visible through graphs that were already frozen. Freezing again without changes
+// in between returns the same graph without rebuilding it.
+func (b *GraphBuilder[T]) Freeze() Graph[T] {
+	if b.frozen != nil {
+		return *b.frozen
+	}
+	// Removed edges leave gaps, so the live ones are numbered anew. The new ids keep their order.
+	renumbered := make([]int, len(b.edges))
+	edgeIDs := make([]int, 0, b.live)
+	edges := make([]Edge[T], 0, b.live)
+	for id, edge := range b.edges {
+		if !b.removed[id] {
+			renumbered[id] = len(edges)
+			edgeIDs = append(edgeIDs, len(edges))
+			edges = append(edges, edge)
+		}
+	}
+	incident := func(ids []int) seqMap[struct{}] {
+		keys := make([]int, 0, len(ids))
+		for _, id := range ids {
+			keys = append(keys, renumbered[id])
+		}
+		return buildSeqMap(keys, make([]struct{}, len(keys)))
+	}
+	nodeIDs := make([]int, len(b.nodes))
+	for id := range nodeIDs {
+		nodeIDs[id] = id
+	}
+	hashes := make([]int, 0, len(b.ids.buckets))
+	buckets := make([][]adjacency[T], 0, len(b.ids.buckets))
+	for hash, entries := range b.ids.buckets {
+		bucket := make([]adjacency[T], 0, len(entries))
+		for _, entry := range entries {
+			bucket = append(bucket, adjacency[T]{
+				node: entry.node,
+				id:   entry.val,
+				out:  incident(b.out[entry.val]),
+				in:   incident(b.in[entry.val]),
+			})
+		}
+		hashes = append(hashes, hash)
+		buckets = append(buckets, bucket)
+	}
+	g := Graph[T]{
+		edges:    buildSeqMap(edgeIDs, edges),
+		nodes:    buildSeqMap(nodeIDs, b.nodes),
+		index:    buildHamt(hashes, buckets),
+		nextEdge: len(edges),
+		nextNode: len(b.nodes),
+		directed: b.directed,
+	}
+	b.frozen = &g
+	return g
+}
@@ -0,0 +1,107 @@
+package graph_test
+
+import (
+	"graph"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGraphBuilder(t *testing.T) {
+	builder := graph.CreateDirectedBuilder[string]()
+	builder.AddNodes(StringNode{"A"}, StringNode{"B"}, StringNode{"A"})
+	builder.AddEdges(
+		graph.CreateEdge[string](StringNode{"A"}, StringNode{"B"}, 0),
+		graph.CreateEdge[string](StringNode{"B"}, StringNode{"C"}, 0),
+	)
+	builder.AddEdge(StringNode{"C"}, StringNode{"A"}, 0)
+	assert.Equal(t, 3, builder.GetNumberOfNodes())
+
+	frozen := builder.Freeze()
+	assert.True(t, frozen.IsDirectedGraph())
+	assert.Equal(t, abc().GetNodes(), frozen.GetNodes())
+	assert.Equal(t, abc().GetEdges(), frozen.GetEdges())
+
+	// Changes after freezing do not leak into the frozen graph.
+	builder.RemoveEdge(StringNode{"C"}, StringNode{"A"}).AddNode(StringNode{"D"})
+	assert.Equal(t, 3, frozen.GetNumberOfEdges())
+	assert.Equal(t, 3, frozen.GetNumberOfNodes())
+	assert.Equal(t, 2, builder.GetNumberOfEdges())
+	assert.Equal(t, 4, builder.GetNumberOfNodes())
+	assert.Empty(t, builder.Freeze().FindEdgesThatLeadTo(StringNode{"A"}))
+}
+
+func TestUndirectedGraphBuilder(t *testing.T) {
+	builder := graph.CreateUndirectedBuilder[int]()
+	builder.AddEdge(NumberNode{1}, NumberNode{2}, 1).AddEdge(NumberNode{2}, NumberNode{1}, 2)
+
+	// Undirected edges can be removed in either orientation, the earliest one goes first.
+	builder.RemoveEdge(NumberNode{2}, NumberNode{1})
+	g := builder.Freeze()
+	assert.False(t, g.IsDirectedGraph())
+	assert.Equal(t, 1, g.GetNumberOfEdges())
+	assert.Equal(t, graph.Node[int](NumberNode{2}), g.GetEdges()[0].U())
+
+	// Removing an edge that does not exist is a no-op.
+	builder.RemoveEdge(NumberNode{1}, NumberNode{3})
+	assert.Equal(t, 1, builder.GetNumberOfEdges())
+
+	extended := g.ToBuilder().AddEdge(NumberNode{2}, NumberNode{3}, 0).Freeze()
+	assert.Equal(t, 2, extended.GetNumberOfEdges())
+	assert.Equal(t, 1, g.GetNumberOfEdges())
+}
+
+func TestGraphBuilderMatchesChainedAdds(t *testing.T) {
+	builder := graph.CreateUndirectedBuilder[int]()
+	chained := graph.CreateUndirected[int]()
+	for i := range 300 {
+		// Every fourth node collides with the others and 32 apart share the low bits of their hash.
+		u, v := graph.Node[int](NumberNode{i % 70 * 32}), graph.Node[int](NumberNode{(i*7 + 1) % 90})
+		if i%4 == 0 {
+			v = CollidingNode{1000 + i%9}
+		}
+		builder.AddEdge(u, v, float64(i))
+		chained = chained.AddEdge(u, v, float64(i))
+		if i%5 == 0 {
+			builder.RemoveEdge(v, u)
+			chained = chained.RemoveEdge(v, u)
+		}
+	}
+	builder.AddNode(NumberNode{-1})
+	chained = chained.AddNode(NumberNode{-1})
+
+	frozen := builder.Freeze()
+	assert.Equal(t, chained.GetNodes(), frozen.GetNodes())
+	assert.Equal(t, chained.GetEdges(), frozen.GetEdges())
+	for _, node := range chained.GetNodes() {
+		assert.Equal(t, chained.FindEdgesThatLeadFrom(node), frozen.FindEdgesThatLeadFrom(node))
+		assert.Equal(t, chained.FindInDegree(node), frozen.FindInDegree(node))
+	}
+	// The frozen graph is an ordinary persistent graph that can be changed further.
+	assert.Equal(t, chained.RemoveNode(CollidingNode{1004}).GetEdges(), frozen.RemoveNode(CollidingNode{1004}).GetEdges())
+	extended := frozen.AddEdge(NumberNode{-1}, NumberNode{-2}, 0)
+	assert.Equal(t, chained.GetNumberOfEdges()+1, extended.GetNumberOfEdges())
+	assert.Equal(t, []graph.Node[int]{NumberNode{-2}}, extended.FindNeighboringNodes(NumberNode{-1}))
+	assert.Equal(t, frozen, builder.Freeze())
+}
+
+func BenchmarkGraphBuilder(b *testing.B) {
+	const edges = 10000
+	b.Run("builder", func(b *testing.B) {
+		for b.Loop() {
+			builder := graph.CreateDirectedBuilder[int]()
+			for i := range edges {
+				builder.AddEdge(NumberNode{i}, NumberNode{(i * 31) % edges}, 0)
+			}
+			builder.Freeze()
+		}
+	})
+	b.Run("chained", func(b *testing.B) {
+		for b.Loop() {
+			g := graph.CreateDirected[int]()
+			for i := range edges {
+				g = g.AddEdge(NumberNode{i}, NumberNode{(i * 31) % edges}, 0)
+			}
+		}
+	})
+}
@@ -0,0 +1,170 @@
+package graph
+
+import (
+	"cmp"
+	"slices"
+)
+
+// Computes the strongly connected components of this graph with Tarjan's algorithm. Components are ordered so that
+// edges between two components always lead from an earlier to a later one, and the nodes of a component keep the order
+// they were added in. In an undirected graph every edge leads both ways, so these are its connected components.
+func (g Graph[T]) StronglyConnectedComponents() [][]Node[T] {
+	ig := g.toIndexed()
+	components := [][]Node[T]{}
+	for _, component := range ig.sccsInTopologicalOrder() {
+		components = append(components, ig.toNodes(component))
+	}
+	return components
+}
+
+// Tarjan's algorithm emits components in reverse topological order, this flips them around.
+func (ig indexedGraph[T]) sccsInTopologicalOrder() [][]int {
+	all := make([]int, len(ig.nodes))
+	alive := make([]bool, len(ig.nodes))
+	for node := range all {
+		all[node] = node
+		alive[node] = true
+	}
+	components := ig.stronglyConnected(all, alive)
+	slices.Reverse(components)
+	return components
+}
+
+// A strongly connected component acting as a single node of a condensation graph, see Condensation. Components are
+// identified by their position in the topological order of the condensation they came from, so components of
+// different condensations should not be mixed.
+type ComponentNode[T any] struct {
+	id    int
+	nodes []Node[T]
+}
+
+// Returns the values of the nodes within this component.
+func (c ComponentNode[T]) Val() []T {
+	return nodeValues(c.nodes)
+}
+
+// Returns the nodes within this component.
+func (c ComponentNode[T]) Nodes() []Node[T] {
+	return slices.Clone(c.nodes)
+}
+
+// Orders components by their topological position.
+func (c ComponentNode[T]) Compare(node Node[[]T]) int {
+	other, ok := node.(ComponentNode[T])
+	if !ok {
+		return -1
+	}
+	return cmp.Compare(c.id, other.id)
+}
+
+func (c ComponentNode[T]) Equal(node Node[[]T]) bool {
+	other, ok := node.(ComponentNode[T])
+	return ok && other.id == c.id
+}
+
+func (c ComponentNode[T]) Hash() int {
+	return c.id
+}
+
+// Collapses every strongly connected component of the graph into a single ComponentNode. The result is always a
+// directed acyclic graph, so it can be fed to TopologicalSorts and friends even when the graph has cycles. A function
+// rather than a method since Go does not allow a method on Graph[T] to return a Graph[[]T]. Two components are joined
+// by a single edge when any edge leads from one to the other, weighted by the lightest such edge.
+func Condensation[T any](g Graph[T]) Graph[[]T] {
+	ig := g.toIndexed()
+	components := ig.sccsInTopologicalOrder()
+	componentOf := make([]int, len(ig.nodes))
+	componentNodes := make([]ComponentNode[T], len(components))
+	for id, component := range components {
+		componentNodes[id] = ComponentNode[T]{id: id, nodes: ig.toNodes(component)}
+		for _, node := range component {
+			componentOf[node] = id
+		}
+	}
+
+	condensed := CreateDirected[[]T]()
+	for _, component := range componentNodes {
+		condensed = condensed.AddNode(component)
+	}
+	for _, component := range components {
+		from := componentOf[component[0]]
+		// Lightest edge to each other component, in the order the components are first reached.
+		lightest := map[int]float64{}
+		targets := []int{}
+		for _, node := range component {
+			for _, edge := range ig.out[node] {
+				to := componentOf[edge.to]
+				if to == from {
+					continue
+				}
+				if weight, ok := lightest[to]; !ok {
+					lightest[to] = edge.weight
+					targets = append(targets, to)
+				} else {
+					lightest[to] = min(weight, edge.weight)
+				}
+			}
+		}
+		for _, to := range targets {
+			condensed = condensed.AddEdge(componentNodes[from], componentNodes[to], lightest[to])
+		}
+	}
+	return condensed
+}
+
+// Computes the connected components of this graph. Edge directions are ignored, so on a directed graph these are its
+// weakly connected components. Components are ordered by their earliest added node and nodes within a component keep
+// the order they were added in.
+func (g Graph[T]) ConnectedComponents() [][]Node[T] {
+	return g.disjointSet().Sets()
+}
+
+// Builds a disjoint set where every node shares a set with the nodes it has an edge to or from.
+func (g Graph[T]) disjointSet() *DisjointSet[T] {
+	components := CreateDisjointSet[T]()
+	for _, node := range g.nodes.all() {
+		components.Add(node)
+	}
+	for _, edge := range g.edges.all() {
+		components.Union(edge.u, edge.v)
+	}
+	return components
+}
+
+// Checks if every node can reach every other node while ignoring edge directions. A graph without nodes counts as
+// connected.
+func (g Graph[T]) IsConnected() bool {
+	return g.disjointSet().GetNumberOfSets() <= 1
+}
+
+// Finds the connected component that contains the given node, ignoring edge directions, see ConnectedComponents. Only
+// explores the component itself. Fails with a NodeNotFoundError if the node is not part of this graph.
+func (g Graph[T]) ComponentOf(node Node[T]) ([]Node[T], error) {
+	if !g.ContainsNode(node) {
+		return nil, &NodeNotFoundError[T]{Node: node}
+	}
+	visited := newNodeSet[T]()
+	visited.add(node)
+	component := []Node[T]{node}
+	for next := 0; next < len(component); next++ {
+		curr := component[next]
+		edges := slices.Concat(g.FindEdgesThatLeadFrom(curr), g.FindEdgesThatLeadTo(curr))
+		for _, edge := range edges {
+			for _, neighbor := range []Node[T]{edge.u, edge.v} {
+				if !visited.has(neighbor) {
+					visited.add(neighbor)
+					component = append(component, neighbor)
+				}
+			}
+		}
+	}
+	// Report the nodes in the order they were added to the graph, like ConnectedComponents.
+	addedAt := func(n Node[T]) int {
+		adj, _ := g.lookup(n)
+		return adj.id
+	}
+	slices.SortFunc(component, func(a, b Node[T]) int {
+		return cmp.Compare(addedAt(a), addedAt(b))
+	})
+	return component, nil
+}
@@ -0,0 +1,84 @@
+package graph_test
+
+import (
+	"graph"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+/*
+a ⇄ b ──► c ⇄ d ──► e
+*/
+func chainOfCycles() graph.Graph[string] {
+	return graph.CreateDirected[string]().
+		AddEdge(StringNode{"C"}, StringNode{"D"}, 1).
+		AddEdge(StringNode{"A"}, StringNode{"B"}, 1).
+		AddEdge(StringNode{"B"}, StringNode{"A"}, 1).
+		AddEdge(StringNode{"B"}, StringNode{"C"}, 5).
+		AddEdge(StringNode{"A"}, StringNode{"C"}, 2).
+		AddEdge(StringNode{"D"}, StringNode{"C"}, 1).
+		AddEdge(StringNode{"D"}, StringNode{"E"}, 3)
+}
+
+func TestStronglyConnectedComponents(t *testing.T) {
+	assert.Equal(t, [][]graph.Node[string]{{StringNode{"A"}, StringNode{"B"}, StringNode{"C"}}},
+		abc().StronglyConnectedComponents())
+	assert.Len(t, abcNoEdges().StronglyConnectedComponents(), 3)
+
+	// Components come out in topological order.
+	assert.Equal(t, [][]graph.Node[string]{
+		{StringNode{"A"}, StringNode{"B"}},
+		{StringNode{"C"}, StringNode{"D"}},
+		{StringNode{"E"}},
+	}, chainOfCycles().StronglyConnectedComponents())
+}
+
+func TestCondensation(t *testing.T) {
+	condensed := graph.Condensation(chainOfCycles())
+	assert.True(t, condensed.IsDAG())
+	assert.Equal(t, 3, condensed.GetNumberOfNodes())
+	assert.Equal(t, 2, condensed.GetNumberOfEdges())
+
+	nodes := condensed.GetNodes()
+	assert.Equal(t, []string{"A", "B"}, nodes[0].Val())
+	assert.Equal(t, []string{"C", "D"}, nodes[1].Val())
+	assert.Equal(t, []string{"E"}, nodes[2].Val())
+
+	// The lightest of the collapsed edges is kept.
+	edges := condensed.FindEdgesThatLeadFrom(nodes[0])
+	assert.Len(t, edges, 1)
+	assert.Equal(t, 2.0, edges[0].Weight())
+
+	sorts, err := condensed.TopologicalSorts()
+	assert.NoError(t, err)
+	assert.Equal(t, [][]graph.Node[[]string]{nodes}, sorts)
+}
+
+func TestConnectedComponents(t *testing.T) {
+	g := graph.CreateDirected[int]().
+		AddEdge(NumberNode{1}, NumberNode{2}, 0).
+		AddEdge(NumberNode{3}, NumberNode{2}, 0).
+		AddNode(NumberNode{4}).
+		AddEdge(NumberNode{5}, NumberNode{6}, 0)
+
+	// Directions are ignored, so 1 and 3 share a component through 2.
+	assert.Equal(t, [][]graph.Node[int]{
+		{NumberNode{1}, NumberNode{2}, NumberNode{3}},
+		{NumberNode{4}},
+		{NumberNode{5}, NumberNode{6}},
+	}, g.ConnectedComponents())
+	assert.False(t, g.IsConnected())
+
+	component, err := g.ComponentOf(NumberNode{3})
+	assert.NoError(t, err)
+	assert.Equal(t, []graph.Node[int]{NumberNode{1}, NumberNode{2}, NumberNode{3}}, component)
+	_, err = g.ComponentOf(NumberNode{42})
+	assert.ErrorIs(t, err, graph.ErrNodeNotFound)
+
+	connected := g.AddEdge(NumberNode{4}, NumberNode{3}, 0).AddEdge(NumberNode{6}, NumberNode{4}, 0)
+	assert.True(t, connected.IsConnected())
+	assert.True(t, abc().IsConnected())
+	assert.True(t, graph.CreateUndirected[int]().IsConnected())
+	assert.False(t, abcNoEdges().IsConnected())
+}
@@ -0,0 +1,168 @@
+package graph
+
+import (
+	"iter"
+	"slices"
+)
+
+// Lazily enumerates every elementary cycle of this directed graph using Johnson's algorithm. Each cycle follows the
+// same convention as FindCycle: every node leads to the next one and the last node leads back to the first. A cycle
+// is reported once, starting from its earliest added node, and parallel edges do not produce duplicates. Stopping the
+// iteration early stops the search. Only directed graphs are supported, an undirected graph yields no cycles, use
+// FindCycle for those.
+func (g Graph[T]) SimpleCycles() iter.Seq[[]Node[T]] {
+	return g.simpleCycles(0)
+}
+
+// Like SimpleCycles, but only yields cycles with at most maxLength nodes. Johnson's blocking rule does not hold once
+// paths are cut short, so the bounded search falls back to plain backtracking within each strongly connected component,
+// which stays cheap as long as the bound is small.
+func (g Graph[T]) SimpleCyclesUpTo(maxLength int) iter.Seq[[]Node[T]] {
+	if maxLength <= 0 {
+		return func(yield func([]Node[T]) bool) {}
+	}
+	return g.simpleCycles(maxLength)
+}
+
+// Enumerates elementary cycles, a maxLength of 0 means the length is unbounded.
+func (g Graph[T]) simpleCycles(maxLength int) iter.Seq[[]Node[T]] {
+	return func(yield func([]Node[T]) bool) {
+		if !g.directed {
+			return
+		}
+		ig := g.toIndexed()
+		n := len(ig.nodes)
+		// Successors without duplicates or self loops, self loops are reported up front.
+		successors := make([][]int, n)
+		for node, edges := range ig.out {
+			for _, edge := range edges {
+				if edge.to == node {
+					continue
+				}
+				if !slices.Contains(successors[node], edge.to) {
+					successors[node] = append(successors[node], edge.to)
+				}
+			}
+		}
+		for node, edges := range ig.out {
+			if slices.ContainsFunc(edges, func(e indexedEdge) bool { return e.to == node }) {
+				if !yield([]Node[T]{ig.nodes[node]}) {
+					return
+				}
+			}
+		}
+
+		// Scratch state shared by every component, members are reset once their component is done.
+		inComponent := make([]bool, n)
+		blocked := make([]bool, n)
+		closed := make([]bool, n)
+		blockers := make([]map[int]bool, n)
+		unblock := func(node int) {
+			pending := []int{node}
+			for len(pending) > 0 {
+				curr := pending[len(pending)-1]
+				pending = pending[:len(pending)-1]
+				if !blocked[curr] {
+					continue
+				}
+				blocked[curr] = false
+				for blocker := range blockers[curr] {
+					pending = append(pending, blocker)
+				}
+				blockers[curr] = nil
+			}
+		}
+		// Searches every cycle through the least node of the component, returns false once the caller stops.
+		circuits := func(start int) bool {
+			type frame struct {
+				node int
+				next int
+			}
+			path := []int{start}
+			blocked[start] = true
+			stack := []frame{{node: start}}
+			for len(stack) > 0 {
+				top := &stack[len(stack)-1]
+				if top.next < len(successors[top.node]) {
+					next := successors[top.node][top.next]
+					top.next++
+					switch {
+					case !inComponent[next]:
+					case next == start:
+						if !yield(ig.toNodes(path)) {
+							return false
+						}
+						for _, node := range path {
+							closed[node] = true
+						}
+					case blocked[next]:
+					case maxLength > 0 && len(path) >= maxLength:
+					default:
+						path = append(path, next)
+						blocked[next] = true
+						closed[next] = false
+						stack = append(stack, frame{node: next})
+					}
+					continue
+				}
+				node := top.node
+				switch {
+				case maxLength > 0:
+					blocked[node] = false
+				case closed[node]:
+					unblock(node)
+				default:
+					// The node stays blocked until one of its successors finds its way back to the start.
+					for _, successor := range successors[node] {
+						if inComponent[successor] {
+							if blockers[successor] == nil {
+								blockers[successor] = map[int]bool{}
+							}
+							blockers[successor][node] = true
+						}
+					}
+				}
+				stack = stack[:len(stack)-1]
+				path = path[:len(path)-1]
+			}
+			return true
+		}
+
+		all := make([]int, n)
+		alive := make([]bool, n)
+		for node := range n {
+			all[node] = node
+			alive[node] = true
+		}
+		components := [][]int{}
+		for _, component := range ig.stronglyConnected(all, alive) {
+			if len(component) > 1 {
+				components = append(components, component)
+			}
+		}
+		for len(components) > 0 {
+			component := components[len(components)-1]
+			components = components[:len(components)-1]
+			for _, member := range component {
+				inComponent[member] = true
+			}
+			start := component[0]
+			if !circuits(start) {
+				return
+			}
+			for _, member := range component {
+				blocked[member], closed[member], blockers[member] = false, false, nil
+			}
+			// Every cycle through the start has been found, so the rest of the component is searched without it.
+			inComponent[start] = false
+			for _, sub := range ig.stronglyConnected(component[1:], inComponent) {
+				if len(sub) > 1 {
+					components = append(components, sub)
+				}
+			}
+			for _, member := range component {
+				inComponent[member] = false
+			}
+		}
+	}
+}
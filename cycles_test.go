@@ -0,0 +1,90 @@
+package graph_test
+
+import (
+	"graph"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func completeDirected(n int) graph.Graph[int] {
+	g := graph.CreateDirected[int]()
+	for u := range n {
+		for v := range n {
+			if u != v {
+				g = g.AddEdge(NumberNode{u}, NumberNode{v}, 0)
+			}
+		}
+	}
+	return g
+}
+
+func TestSimpleCycles(t *testing.T) {
+	cycles := [][]graph.Node[string]{}
+	for cycle := range abc().SimpleCycles() {
+		cycles = append(cycles, cycle)
+	}
+	assert.Equal(t, [][]graph.Node[string]{{StringNode{"A"}, StringNode{"B"}, StringNode{"C"}}}, cycles)
+
+	for range abcNoEdges().SimpleCycles() {
+		assert.Fail(t, "a graph without edges has no cycles")
+	}
+
+	// A complete directed graph on 4 nodes has 6 cycles of length 2, 8 of length 3 and 6 of length 4.
+	counts := map[int]int{}
+	seen := map[[4]int]bool{}
+	for cycle := range completeDirected(4).SimpleCycles() {
+		counts[len(cycle)]++
+		key := [4]int{-1, -1, -1, -1}
+		for idx, node := range cycle {
+			key[idx] = node.Val()
+		}
+		assert.False(t, seen[key], "cycle %v reported twice", key)
+		seen[key] = true
+		// Cycles start from their earliest added node.
+		for _, node := range cycle {
+			assert.LessOrEqual(t, cycle[0].Val(), node.Val())
+		}
+	}
+	assert.Equal(t, map[int]int{2: 6, 3: 8, 4: 6}, counts)
+}
+
+func TestSimpleCyclesSelfLoopsAndParallelEdges(t *testing.T) {
+	g := graph.CreateDirected[int]().
+		AddEdge(NumberNode{1}, NumberNode{1}, 0).
+		AddEdge(NumberNode{1}, NumberNode{2}, 0).
+		AddEdge(NumberNode{1}, NumberNode{2}, 0).
+		AddEdge(NumberNode{2}, NumberNode{1}, 0)
+	cycles := [][]graph.Node[int]{}
+	for cycle := range g.SimpleCycles() {
+		cycles = append(cycles, cycle)
+	}
+	assert.Equal(t, [][]graph.Node[int]{{NumberNode{1}}, {NumberNode{1}, NumberNode{2}}}, cycles)
+
+	// Undirected graphs are not supported.
+	for range graph.CreateUndirected[int]().AddEdge(NumberNode{1}, NumberNode{1}, 0).SimpleCycles() {
+		assert.Fail(t, "undirected graphs yield no cycles")
+	}
+}
+
+func TestSimpleCyclesUpTo(t *testing.T) {
+	counts := map[int]int{}
+	for cycle := range completeDirected(5).SimpleCyclesUpTo(3) {
+		counts[len(cycle)]++
+	}
+	assert.Equal(t, map[int]int{2: 10, 3: 20}, counts)
+
+	for range completeDirected(3).SimpleCyclesUpTo(0) {
+		assert.Fail(t, "no cycle has at most 0 nodes")
+	}
+
+	// Stopping early ends the search.
+	found := 0
+	for range completeDirected(8).SimpleCycles() {
+		found++
+		if found == 3 {
+			break
+		}
+	}
+	assert.Equal(t, 3, found)
+}
@@ -0,0 +1,227 @@
+package graph
+
+import "slices"
+
+// How the depth first search that built a DepthFirstForest used an edge.
+type EdgeKind int
+
+const (
+	// The edge discovered its target, i.e. it belongs to the forest.
+	TreeEdge EdgeKind = iota
+	// The edge leads back to an ancestor that is still being explored, including self loops. A directed graph has a
+	// cycle exactly if there is one.
+	BackEdge
+	// The edge leads to a descendant that was already discovered over another path. Never found in undirected graphs.
+	ForwardEdge
+	// The edge leads to a node in another, already finished subtree. Never found in undirected graphs.
+	CrossEdge
+)
+
+func (k EdgeKind) String() string {
+	switch k {
+	case TreeEdge:
+		return "tree"
+	case BackEdge:
+		return "back"
+	case ForwardEdge:
+		return "forward"
+	case CrossEdge:
+		return "cross"
+	}
+	return "unknown"
+}
+
+// An edge together with how the depth first search used it. Edges of undirected graphs are oriented in the direction
+// the search first walked them.
+type ClassifiedEdge[T any] struct {
+	Edge Edge[T]
+	Kind EdgeKind
+}
+
+// The result of a depth first search, see DFSForest. Discovery and finish times share a single clock that starts at 1
+// and ticks once per event, so a node is a descendant of another exactly if its interval lies within the other one.
+type DepthFirstForest[T any] struct {
+	ig         indexedGraph[T]
+	discovered []int // 0 for nodes the search never reached
+	finished   []int
+	parent     []int // -1 for roots and nodes the search never reached
+	preorder   []int
+	postorder  []int
+	edges      []ClassifiedEdge[T]
+}
+
+// Runs an iterative depth first search from every given source in turn, skipping sources an earlier search already
+// reached. Without sources every node of this graph is used as a source in the order they were added, so the forest
+// covers the whole graph. Neighbors are visited in the order of the graph comparator, like DFS. Fails with a
+// NodeNotFoundError if a source is not part of this graph.
+func (g Graph[T]) DFSForest(sources ...Node[T]) (DepthFirstForest[T], error) {
+	ig := g.toIndexed()
+	roots := make([]int, 0, len(sources))
+	for _, source := range sources {
+		id, ok := ig.id(source)
+		if !ok {
+			return DepthFirstForest[T]{}, &NodeNotFoundError[T]{Node: source}
+		}
+		roots = append(roots, id)
+	}
+	if len(sources) == 0 {
+		for node := range ig.nodes {
+			roots = append(roots, node)
+		}
+	}
+
+	// Every edge is listed under the node it leaves, and in an undirected graph under the node it enters as well. The
+	// id makes sure an undirected edge is only classified the first time it is walked.
+	type incidence struct {
+		edge Edge[T]
+		to   int
+		id   int
+	}
+	incident := make([][]incidence, len(ig.nodes))
+	for id, edge := range g.GetEdges() {
+		u, _ := ig.id(edge.u)
+		v, _ := ig.id(edge.v)
+		incident[u] = append(incident[u], incidence{edge, v, id})
+		if !g.directed && u != v {
+			incident[v] = append(incident[v], incidence{edge.reverse(), u, id})
+		}
+	}
+	for node := range incident {
+		slices.SortStableFunc(incident[node], func(a, b incidence) int {
+			return ig.nodes[a.to].Compare(ig.nodes[b.to])
+		})
+	}
+
+	forest := DepthFirstForest[T]{
+		ig:         ig,
+		discovered: make([]int, len(ig.nodes)),
+		finished:   make([]int, len(ig.nodes)),
+		parent:     make([]int, len(ig.nodes)),
+		preorder:   []int{},
+		postorder:  []int{},
+		edges:      []ClassifiedEdge[T]{},
+	}
+	classified := map[int]bool{}
+	clock := 0
+	discover := func(node int, parent int) {
+		clock++
+		forest.discovered[node] = clock
+		forest.parent[node] = parent
+		forest.preorder = append(forest.preorder, node)
+	}
+	type frame struct {
+		node int
+		next int
+	}
+	for _, root := range roots {
+		if forest.discovered[root] != 0 {
+			continue
+		}
+		discover(root, -1)
+		stack := []frame{{node: root}}
+		for len(stack) > 0 {
+			top := &stack[len(stack)-1]
+			if top.next == len(incident[top.node]) {
+				clock++
+				forest.finished[top.node] = clock
+				forest.postorder = append(forest.postorder, top.node)
+				stack = stack[:len(stack)-1]
+				continue
+			}
+			next := incident[top.node][top.next]
+			top.next++
+			if classified[next.id] {
+				continue
+			}
+			classified[next.id] = true
+			kind := CrossEdge
+			switch {
+			case forest.discovered[next.to] == 0:
+				kind = TreeEdge
+				discover(next.to, top.node)
+				stack = append(stack, frame{node: next.to})
+			case forest.finished[next.to] == 0:
+				kind = BackEdge
+			case forest.discovered[top.node] < forest.discovered[next.to]:
+				kind = ForwardEdge
+			}
+			forest.edges = append(forest.edges, ClassifiedEdge[T]{next.edge, kind})
+		}
+	}
+	for node := range forest.parent {
+		if forest.discovered[node] == 0 {
+			forest.parent[node] = -1
+		}
+	}
+	return forest, nil
+}
+
+func (f DepthFirstForest[T]) reached(node Node[T]) (int, bool) {
+	id, ok := f.ig.id(node)
+	if !ok || f.discovered[id] == 0 {
+		return -1, false
+	}
+	return id, true
+}
+
+// Returns the nodes each tree of the forest was grown from, in the order the search started from them.
+func (f DepthFirstForest[T]) Roots() []Node[T] {
+	roots := []Node[T]{}
+	for _, node := range f.preorder {
+		if f.parent[node] == -1 {
+			roots = append(roots, f.ig.nodes[node])
+		}
+	}
+	return roots
+}
+
+// Checks if the search reached the given node.
+func (f DepthFirstForest[T]) IsReachable(node Node[T]) bool {
+	_, ok := f.reached(node)
+	return ok
+}
+
+// Returns the time the search discovered the node at. Reports false if the search never reached it.
+func (f DepthFirstForest[T]) Discovered(node Node[T]) (int, bool) {
+	id, ok := f.reached(node)
+	if !ok {
+		return 0, false
+	}
+	return f.discovered[id], true
+}
+
+// Returns the time the search finished exploring everything below the node at. Reports false if the search never
+// reached it.
+func (f DepthFirstForest[T]) Finished(node Node[T]) (int, bool) {
+	id, ok := f.reached(node)
+	if !ok {
+		return 0, false
+	}
+	return f.finished[id], true
+}
+
+// Returns the node the search discovered the given node from. Reports false for roots and nodes the search never
+// reached.
+func (f DepthFirstForest[T]) Parent(node Node[T]) (Node[T], bool) {
+	id, ok := f.reached(node)
+	if !ok || f.parent[id] == -1 {
+		return nil, false
+	}
+	return f.ig.nodes[f.parent[id]], true
+}
+
+// Returns the reached nodes in the order they were discovered.
+func (f DepthFirstForest[T]) Preorder() []Node[T] {
+	return f.ig.toNodes(f.preorder)
+}
+
+// Returns the reached nodes in the order they were finished. Reversed, this is a topological order if there are no
+// back edges.
+func (f DepthFirstForest[T]) Postorder() []Node[T] {
+	return f.ig.toNodes(f.postorder)
+}
+
+// Returns every edge the search walked, in the order it walked them, together with its kind.
+func (f DepthFirstForest[T]) Edges() []ClassifiedEdge[T] {
+	return slices.Clone(f.edges)
+}
@@ -0,0 +1,119 @@
+package graph_test
+
+import (
+	"graph"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+/*
+1 ──► 2 ──► 3
+│  ╲  ▲     │
+│   ╲ └─────┘
+▼    ▼
+4 ──► 5 ◄── 6
+*/
+func forestGraph() graph.Graph[int] {
+	return graph.CreateDirected[int]().
+		AddEdge(NumberNode{1}, NumberNode{2}, 0).
+		AddEdge(NumberNode{2}, NumberNode{3}, 0).
+		AddEdge(NumberNode{3}, NumberNode{2}, 0).
+		AddEdge(NumberNode{1}, NumberNode{4}, 0).
+		AddEdge(NumberNode{1}, NumberNode{5}, 0).
+		AddEdge(NumberNode{4}, NumberNode{5}, 0).
+		AddEdge(NumberNode{6}, NumberNode{5}, 0).
+		AddEdge(NumberNode{4}, NumberNode{4}, 0)
+}
+
+func kinds(forest graph.DepthFirstForest[int]) map[[2]int]graph.EdgeKind {
+	kinds := map[[2]int]graph.EdgeKind{}
+	for _, edge := range forest.Edges() {
+		kinds[[2]int{edge.Edge.U().Val(), edge.Edge.V().Val()}] = edge.Kind
+	}
+	return kinds
+}
+
+func TestDFSForest(t *testing.T) {
+	forest, err := forestGraph().DFSForest()
+	assert.NoError(t, err)
+	assert.Equal(t, []graph.Node[int]{NumberNode{1}, NumberNode{6}}, forest.Roots())
+	assert.Equal(t, []graph.Node[int]{
+		NumberNode{1}, NumberNode{2}, NumberNode{3}, NumberNode{4}, NumberNode{5}, NumberNode{6},
+	}, forest.Preorder())
+	assert.Equal(t, []graph.Node[int]{
+		NumberNode{3}, NumberNode{2}, NumberNode{5}, NumberNode{4}, NumberNode{1}, NumberNode{6},
+	}, forest.Postorder())
+
+	discovered, _ := forest.Discovered(NumberNode{4})
+	finished, _ := forest.Finished(NumberNode{4})
+	assert.Equal(t, 6, discovered)
+	assert.Equal(t, 9, finished)
+	finished, _ = forest.Finished(NumberNode{1})
+	assert.Equal(t, 10, finished)
+	parent, ok := forest.Parent(NumberNode{5})
+	assert.True(t, ok)
+	assert.Equal(t, graph.Node[int](NumberNode{4}), parent)
+	_, ok = forest.Parent(NumberNode{6})
+	assert.False(t, ok)
+
+	assert.Equal(t, map[[2]int]graph.EdgeKind{
+		{1, 2}: graph.TreeEdge,
+		{2, 3}: graph.TreeEdge,
+		{3, 2}: graph.BackEdge,
+		{1, 4}: graph.TreeEdge,
+		{4, 4}: graph.BackEdge,
+		{4, 5}: graph.TreeEdge,
+		{1, 5}: graph.ForwardEdge,
+		{6, 5}: graph.CrossEdge,
+	}, kinds(forest))
+	assert.Equal(t, "forward", graph.ForwardEdge.String())
+}
+
+func TestDFSForestFromSources(t *testing.T) {
+	forest, err := forestGraph().DFSForest(NumberNode{4})
+	assert.NoError(t, err)
+	assert.Equal(t, []graph.Node[int]{NumberNode{4}, NumberNode{5}}, forest.Preorder())
+	assert.False(t, forest.IsReachable(NumberNode{1}))
+	_, ok := forest.Discovered(NumberNode{1})
+	assert.False(t, ok)
+	assert.Len(t, forest.Edges(), 2)
+
+	// Later sources that were already reached do not start a tree of their own.
+	forest, err = forestGraph().DFSForest(NumberNode{2}, NumberNode{3}, NumberNode{6})
+	assert.NoError(t, err)
+	assert.Equal(t, []graph.Node[int]{NumberNode{2}, NumberNode{6}}, forest.Roots())
+
+	_, err = forestGraph().DFSForest(NumberNode{42})
+	assert.ErrorIs(t, err, graph.ErrNodeNotFound)
+}
+
+func TestDFSForestUndirected(t *testing.T) {
+	g := graph.CreateUndirected[int]().
+		AddEdge(NumberNode{1}, NumberNode{2}, 0).
+		AddEdge(NumberNode{3}, NumberNode{2}, 0).
+		AddEdge(NumberNode{1}, NumberNode{3}, 0).
+		AddEdge(NumberNode{3}, NumberNode{4}, 0)
+	forest, err := g.DFSForest()
+	assert.NoError(t, err)
+	// Every edge is only classified once, in the direction it was walked first.
+	assert.Equal(t, map[[2]int]graph.EdgeKind{
+		{1, 2}: graph.TreeEdge,
+		{2, 3}: graph.TreeEdge,
+		{3, 1}: graph.BackEdge,
+		{3, 4}: graph.TreeEdge,
+	}, kinds(forest))
+}
+
+func TestDeepDFS(t *testing.T) {
+	builder := graph.CreateDirectedBuilder[int]()
+	for node := range 20000 {
+		builder.AddEdge(NumberNode{node}, NumberNode{node + 1}, 0)
+	}
+	g := builder.Freeze()
+	assert.Len(t, g.DFS(NumberNode{0}), 20001)
+	forest, err := g.DFSForest()
+	assert.NoError(t, err)
+	finished, _ := forest.Finished(NumberNode{0})
+	assert.Equal(t, 40002, finished)
+}
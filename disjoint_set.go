@@ -0,0 +1,108 @@
+package graph
+
+// A union-find structure over nodes, keyed by Hash and told apart by Equal like the graph itself. Uses union by rank
+// and path compression, so every operation runs in nearly constant amortized time. Unlike Graph this structure is
+// mutable, it is meant as a building block for algorithms.
+type DisjointSet[T any] struct {
+	ids    *nodeMap[T, int]
+	nodes  []Node[T]
+	parent []int
+	rank   []int
+	sets   int
+}
+
+func CreateDisjointSet[T any]() *DisjointSet[T] {
+	return &DisjointSet[T]{ids: newNodeMap[T, int]()}
+}
+
+// Adds the node as a set of its own. Returns false if the node was already part of this structure.
+func (d *DisjointSet[T]) Add(node Node[T]) bool {
+	if d.ids.has(node) {
+		return false
+	}
+	d.ids.put(node, len(d.nodes))
+	d.parent = append(d.parent, len(d.nodes))
+	d.rank = append(d.rank, 0)
+	d.nodes = append(d.nodes, node)
+	d.sets++
+	return true
+}
+
+func (d *DisjointSet[T]) Contains(node Node[T]) bool {
+	return d.ids.has(node)
+}
+
+func (d *DisjointSet[T]) find(id int) int {
+	root := id
+	for d.parent[root] != root {
+		root = d.parent[root]
+	}
+	// Point every node on the way directly at the root.
+	for d.parent[id] != root {
+		d.parent[id], id = root, d.parent[id]
+	}
+	return root
+}
+
+// Finds the representative of the set containing the node. Reports false if the node was never added.
+func (d *DisjointSet[T]) Find(node Node[T]) (Node[T], bool) {
+	id, ok := d.ids.get(node)
+	if !ok {
+		return nil, false
+	}
+	return d.nodes[d.find(id)], true
+}
+
+// Merges the sets containing both nodes, adding either node first if it is missing. Returns false if they were already
+// in the same set.
+func (d *DisjointSet[T]) Union(a Node[T], b Node[T]) bool {
+	d.Add(a)
+	d.Add(b)
+	idA, _ := d.ids.get(a)
+	idB, _ := d.ids.get(b)
+	rootA, rootB := d.find(idA), d.find(idB)
+	if rootA == rootB {
+		return false
+	}
+	if d.rank[rootA] < d.rank[rootB] {
+		rootA, rootB = rootB, rootA
+	}
+	d.parent[rootB] = rootA
+	if d.rank[rootA] == d.rank[rootB] {
+		d.rank[rootA]++
+	}
+	d.sets--
+	return true
+}
+
+// Checks if both nodes were added and belong to the same set.
+func (d *DisjointSet[T]) Connected(a Node[T], b Node[T]) bool {
+	idA, okA := d.ids.get(a)
+	idB, okB := d.ids.get(b)
+	return okA && okB && d.find(idA) == d.find(idB)
+}
+
+func (d *DisjointSet[T]) GetNumberOfSets() int {
+	return d.sets
+}
+
+func (d *DisjointSet[T]) GetNumberOfNodes() int {
+	return len(d.nodes)
+}
+
+// Returns every set, ordered by the earliest added node in each. Nodes within a set keep the order they were added in.
+func (d *DisjointSet[T]) Sets() [][]Node[T] {
+	position := map[int]int{}
+	sets := [][]Node[T]{}
+	for id, node := range d.nodes {
+		root := d.find(id)
+		pos, ok := position[root]
+		if !ok {
+			pos = len(sets)
+			position[root] = pos
+			sets = append(sets, []Node[T]{})
+		}
+		sets[pos] = append(sets[pos], node)
+	}
+	return sets
+}
@@ -0,0 +1,37 @@
+package graph_test
+
+import (
+	"graph"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDisjointSet(t *testing.T) {
+	set := graph.CreateDisjointSet[int]()
+	assert.True(t, set.Add(NumberNode{1}))
+	assert.False(t, set.Add(NumberNode{1}))
+	assert.True(t, set.Union(CollidingNode{2}, CollidingNode{3}))
+	assert.False(t, set.Union(CollidingNode{3}, CollidingNode{2}))
+	set.Add(NumberNode{4})
+
+	// Colliding hashes do not merge distinct nodes.
+	assert.True(t, set.Connected(CollidingNode{2}, CollidingNode{3}))
+	assert.False(t, set.Connected(CollidingNode{2}, NumberNode{1}))
+	assert.False(t, set.Connected(NumberNode{1}, NumberNode{42}))
+	assert.Equal(t, 3, set.GetNumberOfSets())
+	assert.Equal(t, 4, set.GetNumberOfNodes())
+
+	assert.True(t, set.Union(NumberNode{4}, CollidingNode{3}))
+	rootA, ok := set.Find(NumberNode{4})
+	assert.True(t, ok)
+	rootB, _ := set.Find(CollidingNode{2})
+	assert.Equal(t, rootA, rootB)
+	_, ok = set.Find(NumberNode{42})
+	assert.False(t, ok)
+
+	assert.Equal(t, [][]graph.Node[int]{
+		{NumberNode{1}},
+		{CollidingNode{2}, CollidingNode{3}, NumberNode{4}},
+	}, set.Sets())
+}
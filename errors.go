@@ -0,0 +1,117 @@
+package graph
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Sentinel errors reported by the error returning algorithms. The concrete errors wrap one of these, so callers can
+// match them with errors.Is and reach the extra context with errors.As.
+var (
+	ErrNotDAG                = errors.New("graph is not a directed acyclic graph")
+	ErrNegativeWeight        = errors.New("graph contains a negative edge weight")
+	ErrNodeNotFound          = errors.New("node is not part of the graph")
+	ErrUndirectedGraph       = errors.New("operation requires a directed graph")
+	ErrDirectedGraph         = errors.New("operation requires an undirected graph")
+	ErrNegativeCycle         = errors.New("graph contains a negative cycle")
+	ErrNoPath                = errors.New("no path between the nodes")
+	ErrInconsistentHeuristic = errors.New("heuristic is not consistent")
+	ErrSourceIsSink          = errors.New("source and sink are the same node")
+	ErrInsufficientCapacity  = errors.New("demand exceeds the capacity between source and sink")
+	ErrNotBipartite          = errors.New("graph is not bipartite")
+	ErrNoPerfectMatching     = errors.New("graph has no perfect matching")
+)
+
+// Reported when a directed acyclic graph was required. Cycle lists the nodes of one cycle in the graph, every node
+// leads to the next one and the last node leads back to the first.
+type NotDAGError[T any] struct {
+	Cycle []Node[T]
+}
+
+func (e *NotDAGError[T]) Error() string {
+	return fmt.Sprintf("%v: found cycle %v", ErrNotDAG, nodeValues(e.Cycle))
+}
+
+func (e *NotDAGError[T]) Unwrap() error {
+	return ErrNotDAG
+}
+
+// Reported when an algorithm cannot handle the negative weight of Edge.
+type NegativeWeightError[T any] struct {
+	Edge Edge[T]
+}
+
+func (e *NegativeWeightError[T]) Error() string {
+	return fmt.Sprintf("%v: edge %v -> %v has weight %v", ErrNegativeWeight, e.Edge.u.Val(), e.Edge.v.Val(), e.Edge.weight)
+}
+
+func (e *NegativeWeightError[T]) Unwrap() error {
+	return ErrNegativeWeight
+}
+
+// Reported when shortest paths are undefined because a cycle of negative total weight can be reached. Cycle lists its
+// nodes in the same order as NotDAGError.
+type NegativeCycleError[T any] struct {
+	Cycle []Node[T]
+}
+
+func (e *NegativeCycleError[T]) Error() string {
+	return fmt.Sprintf("%v: found cycle %v", ErrNegativeCycle, nodeValues(e.Cycle))
+}
+
+func (e *NegativeCycleError[T]) Unwrap() error {
+	return ErrNegativeCycle
+}
+
+// Reported when a bipartite graph was required. Cycle lists the nodes of a cycle of odd length in the same order as
+// NotDAGError, which rules out splitting the nodes into two sides.
+type OddCycleError[T any] struct {
+	Cycle []Node[T]
+}
+
+func (e *OddCycleError[T]) Error() string {
+	return fmt.Sprintf("%v: found odd cycle %v", ErrNotBipartite, nodeValues(e.Cycle))
+}
+
+func (e *OddCycleError[T]) Unwrap() error {
+	return ErrNotBipartite
+}
+
+// Reported by AStarDebug when the heuristic drops by more than the weight of Edge, or does not estimate 0 at the
+// target. Edge is the zero value in the latter case.
+type InconsistentHeuristicError[T any] struct {
+	Edge Edge[T]
+}
+
+func (e *InconsistentHeuristicError[T]) Error() string {
+	if e.Edge.u == nil {
+		return fmt.Sprintf("%v: estimate at the target is not 0", ErrInconsistentHeuristic)
+	}
+	return fmt.Sprintf("%v: estimate drops by more than %v along edge %v -> %v",
+		ErrInconsistentHeuristic, e.Edge.weight, e.Edge.u.Val(), e.Edge.v.Val())
+}
+
+func (e *InconsistentHeuristicError[T]) Unwrap() error {
+	return ErrInconsistentHeuristic
+}
+
+// Reported when an algorithm is handed a Node that is not part of the graph.
+type NodeNotFoundError[T any] struct {
+	Node Node[T]
+}
+
+func (e *NodeNotFoundError[T]) Error() string {
+	return fmt.Sprintf("%v: %v", ErrNodeNotFound, e.Node.Val())
+}
+
+func (e *NodeNotFoundError[T]) Unwrap() error {
+	return ErrNodeNotFound
+}
+
+func nodeValues[T any](nodes []Node[T]) []T {
+	values := make([]T, 0, len(nodes))
+	for _, node := range nodes {
+		values = append(values, node.Val())
+	}
+	return values
+}
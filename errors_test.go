@@ -0,0 +1,53 @@
+package graph_test
+
+import (
+	"errors"
+	"graph"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTopologicalSortsErrors(t *testing.T) {
+	_, err := abc().TopologicalSorts()
+	assert.ErrorIs(t, err, graph.ErrNotDAG)
+	var notDAG *graph.NotDAGError[string]
+	assert.True(t, errors.As(err, &notDAG))
+	assert.Equal(t, []graph.Node[string]{StringNode{"A"}, StringNode{"B"}, StringNode{"C"}}, notDAG.Cycle)
+
+	_, err = graph.CreateUndirected[string]().AddEdge(StringNode{"A"}, StringNode{"B"}, 0).TopologicalSorts()
+	assert.ErrorIs(t, err, graph.ErrUndirectedGraph)
+
+	sorts, err := abcNoEdges().TopologicalSorts()
+	assert.NoError(t, err)
+	assert.Len(t, sorts, 6)
+}
+
+func TestShortestPathsErrors(t *testing.T) {
+	g := graph.CreateDirected[int]().
+		AddEdge(NumberNode{1}, NumberNode{2}, 3).
+		AddEdge(NumberNode{2}, NumberNode{3}, -1)
+
+	_, err := g.ShortestPaths(NumberNode{1})
+	assert.ErrorIs(t, err, graph.ErrNegativeWeight)
+	var negative *graph.NegativeWeightError[int]
+	assert.True(t, errors.As(err, &negative))
+	assert.Equal(t, graph.Node[int](NumberNode{2}), negative.Edge.U())
+	assert.Equal(t, graph.Node[int](NumberNode{3}), negative.Edge.V())
+	assert.Panics(t, func() { g.Dijkstras(NumberNode{1}) })
+
+	_, err = g.ShortestPaths(NumberNode{42})
+	assert.ErrorIs(t, err, graph.ErrNodeNotFound)
+	var notFound *graph.NodeNotFoundError[int]
+	assert.True(t, errors.As(err, &notFound))
+	assert.Equal(t, graph.Node[int](NumberNode{42}), notFound.Node)
+	assert.EqualError(t, err, "node is not part of the graph: 42")
+	// Dijkstras only panics on negative weights, a missing root simply reaches nothing.
+	nonNegative := g.RemoveEdge(NumberNode{2}, NumberNode{3})
+	assert.NotPanics(t, func() { assert.Empty(t, nonNegative.Dijkstras(NumberNode{42})) })
+	assert.Len(t, nonNegative.Dijkstras(NumberNode{1}), 2)
+
+	paths, err := g.RemoveEdge(NumberNode{2}, NumberNode{3}).ShortestPaths(NumberNode{1})
+	assert.NoError(t, err)
+	assert.Equal(t, []graph.Node[int]{NumberNode{1}, NumberNode{2}}, paths[2])
+}
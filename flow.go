@@ -0,0 +1,289 @@
+package graph
+
+import "math"
+
+// A flow from a source to a sink. A maximum flow comes with a minimum cut that proves it is maximal.
+type Flow[T any] struct {
+	Value float64
+	// The total cost of the flow, every unit pays the cost of each edge it runs over. See MinCostFlow.
+	Cost float64
+	// The flow routed over every edge of the graph, in the same order as GetEdges. Undirected edges carry flow either
+	// way, a negative flow runs from V to U.
+	Edges []EdgeFlow[T]
+	// The nodes that can still be reached from the source without saturating an edge, and every other node on the sink
+	// side. For a maximum flow this is a minimum cut, the capacities of the edges between both sides add up to Value.
+	SourceSide []Node[T]
+	SinkSide   []Node[T]
+}
+
+type EdgeFlow[T any] struct {
+	Edge Edge[T]
+	Flow float64
+}
+
+// The residual network of a graph. Every edge becomes an arc and a reverse arc stored next to each other, so arc^1 is
+// always the reverse of arc and the edge with id k owns arcs 2k and 2k+1.
+type flowNetwork[T any] struct {
+	ig       indexedGraph[T]
+	edges    []Edge[T]
+	to       []int
+	residual []float64
+	capacity []float64 // the residual capacity each arc started out with
+	cost     []float64 // the cost of pushing a unit along each arc, pushing it back refunds it
+	arcs     [][]int   // the arcs leaving each node
+	source   int
+	sink     int
+}
+
+// Builds the residual network of this graph with edge weights as capacities. An undirected edge can be used in both
+// directions, so its reverse arc starts out with the full capacity as well.
+func (g Graph[T]) flowNetwork(source Node[T], sink Node[T]) (*flowNetwork[T], error) {
+	ig := g.toIndexed()
+	ids := make([]int, 0, 2)
+	for _, node := range []Node[T]{source, sink} {
+		id, ok := ig.id(node)
+		if !ok {
+			return nil, &NodeNotFoundError[T]{Node: node}
+		}
+		ids = append(ids, id)
+	}
+	if ids[0] == ids[1] {
+		return nil, ErrSourceIsSink
+	}
+	edges := g.GetEdges()
+	network := &flowNetwork[T]{
+		ig:       ig,
+		edges:    edges,
+		to:       make([]int, 0, 2*len(edges)),
+		residual: make([]float64, 0, 2*len(edges)),
+		cost:     make([]float64, 0, 2*len(edges)),
+		arcs:     make([][]int, len(ig.nodes)),
+		source:   ids[0],
+		sink:     ids[1],
+	}
+	for _, edge := range edges {
+		if edge.weight < 0 {
+			return nil, &NegativeWeightError[T]{Edge: edge}
+		}
+		u, _ := ig.id(edge.u)
+		v, _ := ig.id(edge.v)
+		reverse := 0.0
+		if !g.directed {
+			reverse = edge.weight
+		}
+		network.addArc(u, v, edge.weight, edge.cost)
+		network.addArc(v, u, reverse, -edge.cost)
+	}
+	network.capacity = append([]float64(nil), network.residual...)
+	return network, nil
+}
+
+func (n *flowNetwork[T]) addArc(from int, to int, capacity float64, cost float64) {
+	n.arcs[from] = append(n.arcs[from], len(n.to))
+	n.to = append(n.to, to)
+	n.residual = append(n.residual, capacity)
+	n.cost = append(n.cost, cost)
+}
+
+// Pushes the amount along the arc, freeing up the same amount on its reverse.
+func (n *flowNetwork[T]) push(arc int, amount float64) {
+	n.residual[arc] -= amount
+	n.residual[arc^1] += amount
+}
+
+// Numbers every node by its distance from the source in the residual network, -1 for nodes that cannot be reached.
+func (n *flowNetwork[T]) levels() []int {
+	level := make([]int, len(n.arcs))
+	for node := range level {
+		level[node] = -1
+	}
+	level[n.source] = 0
+	queue := []int{n.source}
+	for len(queue) > 0 {
+		curr := queue[0]
+		queue = queue[1:]
+		for _, arc := range n.arcs[curr] {
+			if next := n.to[arc]; n.residual[arc] > 0 && level[next] == -1 {
+				level[next] = level[curr] + 1
+				queue = append(queue, next)
+			}
+		}
+	}
+	return level
+}
+
+// Reads the flow off the residual network once no more flow can be pushed from the source to the sink.
+func (n *flowNetwork[T]) result() Flow[T] {
+	flow := Flow[T]{Edges: make([]EdgeFlow[T], 0, len(n.edges)), SourceSide: []Node[T]{}, SinkSide: []Node[T]{}}
+	for id, edge := range n.edges {
+		flow.Edges = append(flow.Edges, EdgeFlow[T]{Edge: edge, Flow: n.capacity[2*id] - n.residual[2*id]})
+		flow.Cost += math.Abs(flow.Edges[id].Flow) * edge.cost
+	}
+	for _, arc := range n.arcs[n.source] {
+		flow.Value += n.capacity[arc] - n.residual[arc]
+	}
+	for node, level := range n.levels() {
+		if level == -1 {
+			flow.SinkSide = append(flow.SinkSide, n.ig.nodes[node])
+		} else {
+			flow.SourceSide = append(flow.SourceSide, n.ig.nodes[node])
+		}
+	}
+	return flow
+}
+
+// Computes a maximum flow from the source to the sink with Dinic's algorithm, treating edge weights as capacities.
+// Fails with a NodeNotFoundError if either node is not part of this graph, with ErrSourceIsSink if both are the same
+// node and with a NegativeWeightError if any capacity is negative.
+func (g Graph[T]) MaxFlow(source Node[T], sink Node[T]) (Flow[T], error) {
+	network, err := g.flowNetwork(source, sink)
+	if err != nil {
+		return Flow[T]{}, err
+	}
+	network.dinic()
+	return network.result(), nil
+}
+
+// Repeatedly layers the residual network by distance from the source and saturates it with a blocking flow along
+// arcs that lead one layer further.
+func (n *flowNetwork[T]) dinic() {
+	for {
+		level := n.levels()
+		if level[n.sink] == -1 {
+			return
+		}
+		// The next arc to try for every node, arcs before it are known to be useless in this phase.
+		next := make([]int, len(n.arcs))
+		var augment func(node int, limit float64) float64
+		augment = func(node int, limit float64) float64 {
+			if node == n.sink {
+				return limit
+			}
+			for ; next[node] < len(n.arcs[node]); next[node]++ {
+				arc := n.arcs[node][next[node]]
+				to := n.to[arc]
+				if n.residual[arc] <= 0 || level[to] != level[node]+1 {
+					continue
+				}
+				if pushed := augment(to, min(limit, n.residual[arc])); pushed > 0 {
+					n.push(arc, pushed)
+					return pushed
+				}
+			}
+			return 0
+		}
+		for augment(n.source, math.Inf(1)) > 0 {
+		}
+	}
+}
+
+// Computes a maximum flow like MaxFlow with the Edmonds-Karp algorithm, which augments along one shortest path at a
+// time. Simple and predictable, but slower than MaxFlow on larger graphs. Fails like MaxFlow.
+func (g Graph[T]) MaxFlowEdmondsKarp(source Node[T], sink Node[T]) (Flow[T], error) {
+	network, err := g.flowNetwork(source, sink)
+	if err != nil {
+		return Flow[T]{}, err
+	}
+	network.edmondsKarp()
+	return network.result(), nil
+}
+
+func (n *flowNetwork[T]) edmondsKarp() {
+	for {
+		// The arc each node was first reached over, -1 for nodes that were not reached.
+		via := make([]int, len(n.arcs))
+		for node := range via {
+			via[node] = -1
+		}
+		queue := []int{n.source}
+		for len(queue) > 0 && via[n.sink] == -1 {
+			curr := queue[0]
+			queue = queue[1:]
+			for _, arc := range n.arcs[curr] {
+				if next := n.to[arc]; n.residual[arc] > 0 && next != n.source && via[next] == -1 {
+					via[next] = arc
+					queue = append(queue, next)
+				}
+			}
+		}
+		if via[n.sink] == -1 {
+			return
+		}
+		bottleneck := math.Inf(1)
+		for node := n.sink; node != n.source; node = n.to[via[node]^1] {
+			bottleneck = min(bottleneck, n.residual[via[node]])
+		}
+		for node := n.sink; node != n.source; node = n.to[via[node]^1] {
+			n.push(via[node], bottleneck)
+		}
+	}
+}
+
+// Computes a maximum flow like MaxFlow with the FIFO push-relabel algorithm. Instead of searching for augmenting paths
+// it floods the network from the source and lets every node pass its excess downhill towards the sink, which tends to
+// do well on large dense graphs. Fails like MaxFlow.
+func (g Graph[T]) MaxFlowPushRelabel(source Node[T], sink Node[T]) (Flow[T], error) {
+	network, err := g.flowNetwork(source, sink)
+	if err != nil {
+		return Flow[T]{}, err
+	}
+	network.pushRelabel()
+	return network.result(), nil
+}
+
+// Excess below this is considered rounding noise by pushRelabel.
+const flowTolerance = 1e-9
+
+func (n *flowNetwork[T]) pushRelabel() {
+	height := make([]int, len(n.arcs))
+	excess := make([]float64, len(n.arcs))
+	next := make([]int, len(n.arcs))
+	active := []int{}
+	inQueue := make([]bool, len(n.arcs))
+	height[n.source] = len(n.arcs)
+	pushFrom := func(from int, arc int, amount float64) {
+		to := n.to[arc]
+		n.push(arc, amount)
+		excess[from] -= amount
+		excess[to] += amount
+		if !inQueue[to] && to != n.source && to != n.sink {
+			inQueue[to] = true
+			active = append(active, to)
+		}
+	}
+	for _, arc := range n.arcs[n.source] {
+		if n.residual[arc] > 0 {
+			pushFrom(n.source, arc, n.residual[arc])
+		}
+	}
+	for len(active) > 0 {
+		curr := active[0]
+		active = active[1:]
+		inQueue[curr] = false
+		// Discharge the node completely. Excess that cannot reach the sink climbs above the source and flows back.
+		// Rounding can leave a sliver of excess behind that no arc has room for anymore, which is dropped.
+		for excess[curr] > flowTolerance {
+			if next[curr] == len(n.arcs[curr]) {
+				lowest := -1
+				for _, arc := range n.arcs[curr] {
+					// Self loops never take excess away from the node.
+					if to := n.to[arc]; n.residual[arc] > 0 && to != curr && (lowest == -1 || height[to] < lowest) {
+						lowest = height[to]
+					}
+				}
+				if lowest == -1 {
+					break
+				}
+				height[curr] = lowest + 1
+				next[curr] = 0
+				continue
+			}
+			arc := n.arcs[curr][next[curr]]
+			if n.residual[arc] > 0 && height[curr] == height[n.to[arc]]+1 {
+				pushFrom(curr, arc, min(excess[curr], n.residual[arc]))
+			} else {
+				next[curr]++
+			}
+		}
+	}
+}
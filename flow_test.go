@@ -0,0 +1,163 @@
+package graph_test
+
+import (
+	"graph"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type maxFlow = func(graph.Graph[int], graph.Node[int], graph.Node[int]) (graph.Flow[int], error)
+
+var maxFlows = map[string]maxFlow{
+	"Dinic":       graph.Graph[int].MaxFlow,
+	"EdmondsKarp": graph.Graph[int].MaxFlowEdmondsKarp,
+	"PushRelabel": graph.Graph[int].MaxFlowPushRelabel,
+}
+
+// The flow network from CLRS with source 0 and sink 5, its maximum flow is 23.
+func network() graph.Graph[int] {
+	return graph.CreateDirected[int]().
+		AddEdge(NumberNode{0}, NumberNode{1}, 16).
+		AddEdge(NumberNode{0}, NumberNode{2}, 13).
+		AddEdge(NumberNode{2}, NumberNode{1}, 4).
+		AddEdge(NumberNode{1}, NumberNode{3}, 12).
+		AddEdge(NumberNode{3}, NumberNode{2}, 9).
+		AddEdge(NumberNode{2}, NumberNode{4}, 14).
+		AddEdge(NumberNode{4}, NumberNode{3}, 7).
+		AddEdge(NumberNode{3}, NumberNode{5}, 20).
+		AddEdge(NumberNode{4}, NumberNode{5}, 4).
+		AddNode(NumberNode{6})
+}
+
+// Checks capacities, conservation at every inner node and that the cut is saturated.
+func assertValidFlow(t *testing.T, g graph.Graph[int], flow graph.Flow[int], source int, sink int) {
+	balance := map[int]float64{}
+	for _, edge := range flow.Edges {
+		if g.IsDirectedGraph() {
+			assert.GreaterOrEqual(t, edge.Flow, -1e-9)
+		}
+		assert.LessOrEqual(t, max(edge.Flow, -edge.Flow), edge.Edge.Weight()+1e-9)
+		balance[edge.Edge.U().Val()] -= edge.Flow
+		balance[edge.Edge.V().Val()] += edge.Flow
+	}
+	for node, value := range balance {
+		switch node {
+		case source:
+			assert.InDelta(t, -flow.Value, value, 1e-9)
+		case sink:
+			assert.InDelta(t, flow.Value, value, 1e-9)
+		default:
+			assert.InDelta(t, 0, value, 1e-9)
+		}
+	}
+	sourceSide := map[int]bool{}
+	for _, node := range flow.SourceSide {
+		sourceSide[node.Val()] = true
+	}
+	assert.True(t, sourceSide[source])
+	assert.False(t, sourceSide[sink])
+	assert.Equal(t, g.GetNumberOfNodes(), len(flow.SourceSide)+len(flow.SinkSide))
+	cut := 0.0
+	for _, edge := range g.GetEdges() {
+		u, v := sourceSide[edge.U().Val()], sourceSide[edge.V().Val()]
+		if u && !v || !g.IsDirectedGraph() && v && !u {
+			cut += edge.Weight()
+		}
+	}
+	assert.InDelta(t, flow.Value, cut, 1e-9)
+}
+
+func TestMaxFlow(t *testing.T) {
+	for name, run := range maxFlows {
+		t.Run(name, func(t *testing.T) {
+			flow, err := run(network(), NumberNode{0}, NumberNode{5})
+			assert.NoError(t, err)
+			assert.Equal(t, 23.0, flow.Value)
+			assert.Len(t, flow.Edges, 9)
+			assert.Equal(t, []graph.Node[int]{NumberNode{0}, NumberNode{1}, NumberNode{2}, NumberNode{4}}, flow.SourceSide)
+			assert.Equal(t, []graph.Node[int]{NumberNode{3}, NumberNode{5}, NumberNode{6}}, flow.SinkSide)
+			assertValidFlow(t, network(), flow, 0, 5)
+
+			flow, err = run(network(), NumberNode{5}, NumberNode{0})
+			assert.NoError(t, err)
+			assert.Zero(t, flow.Value)
+			assert.Equal(t, []graph.Node[int]{NumberNode{5}}, flow.SourceSide)
+
+			_, err = run(network(), NumberNode{0}, NumberNode{42})
+			assert.ErrorIs(t, err, graph.ErrNodeNotFound)
+			_, err = run(network(), NumberNode{0}, NumberNode{0})
+			assert.ErrorIs(t, err, graph.ErrSourceIsSink)
+			_, err = run(credits(), NumberNode{1}, NumberNode{4})
+			assert.ErrorIs(t, err, graph.ErrNegativeWeight)
+		})
+	}
+}
+
+func TestMaxFlowUndirected(t *testing.T) {
+	// Flow can run against the direction the middle edge was added in.
+	g := graph.CreateUndirected[int]().
+		AddEdge(NumberNode{1}, NumberNode{2}, 1).
+		AddEdge(NumberNode{1}, NumberNode{3}, 5).
+		AddEdge(NumberNode{4}, NumberNode{3}, 2).
+		AddEdge(NumberNode{2}, NumberNode{3}, 3).
+		AddEdge(NumberNode{2}, NumberNode{4}, 4)
+	for name, run := range maxFlows {
+		t.Run(name, func(t *testing.T) {
+			flow, err := run(g, NumberNode{1}, NumberNode{4})
+			assert.NoError(t, err)
+			assert.Equal(t, 6.0, flow.Value)
+			assert.Equal(t, -2.0, flow.Edges[2].Flow)
+			assert.Equal(t, -3.0, flow.Edges[3].Flow)
+			assertValidFlow(t, g, flow, 1, 4)
+		})
+	}
+}
+
+func TestMaxFlowsAgree(t *testing.T) {
+	random := rand.New(rand.NewSource(4))
+	for round := range 2000 {
+		g := graph.CreateDirected[int]()
+		if round%2 == 1 {
+			g = graph.CreateUndirected[int]()
+		}
+		g = g.AddNode(NumberNode{0}).AddNode(NumberNode{11})
+		for range 40 {
+			capacity := float64(random.Intn(10))
+			// Fractional capacities leave rounding errors behind that must not keep the algorithms busy.
+			if round%4 >= 2 {
+				capacity = random.Float64() * 0.3
+			}
+			g = g.AddEdge(NumberNode{random.Intn(12)}, NumberNode{random.Intn(12)}, capacity)
+		}
+		values := []float64{}
+		for _, run := range maxFlows {
+			flow, err := run(g, NumberNode{0}, NumberNode{11})
+			assert.NoError(t, err)
+			assertValidFlow(t, g, flow, 0, 11)
+			values = append(values, flow.Value)
+		}
+		for _, value := range values {
+			assert.InDelta(t, values[0], value, 1e-9)
+		}
+	}
+}
+
+func TestMaxFlowFractionalDeadEnd(t *testing.T) {
+	// The excess of 0.3 at the dead end flows back as 0.1 and 0.2, which leaves a rounding error behind.
+	deadEnd := graph.CreateDirected[int]().
+		AddEdge(NumberNode{0}, NumberNode{1}, 0.1).
+		AddEdge(NumberNode{0}, NumberNode{1}, 0.2).
+		AddNode(NumberNode{2})
+	for _, g := range []graph.Graph[int]{deadEnd, deadEnd.AddEdge(NumberNode{1}, NumberNode{1}, 1)} {
+		for name, run := range maxFlows {
+			t.Run(name, func(t *testing.T) {
+				flow, err := run(g, NumberNode{0}, NumberNode{2})
+				assert.NoError(t, err)
+				assert.Zero(t, flow.Value)
+				assertValidFlow(t, g, flow, 0, 2)
+			})
+		}
+	}
+}
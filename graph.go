@@ -1,15 +1,20 @@
 package graph
 
 import (
-	"container/heap"
-	"math"
+	"errors"
+	"iter"
+	"maps"
 	"slices"
 )
 
 // Represents a graph of any structure or type.
 type Graph[T any] struct {
-	edges    []Edge[T]
-	nodes    []Node[T]
+	edges seqMap[Edge[T]] // keyed by edge id, ids are handed out in insertion order
+	nodes seqMap[Node[T]] // keyed by node id, ids are handed out in insertion order
+	// Out/in adjacency of every node, bucketed by Hash. Lets neighbor and degree lookups avoid scanning every edge.
+	index    hamt[[]adjacency[T]]
+	nextEdge int
+	nextNode int
 	directed bool
 }
 
@@ -25,75 +30,263 @@ type Edge[T any] struct {
 	u      Node[T]
 	v      Node[T]
 	weight float64
+	cost   float64 // a second attribute next to the weight, e.g. the price per unit of flow, see MinCostFlow
+}
+
+// The edges incident to a single node, referred to by their edge id. Ids are kept in ascending order so the insertion
+// order of the edges is preserved.
+type adjacency[T any] struct {
+	node Node[T]
+	id   int              // key of this node in the graph's node list
+	out  seqMap[struct{}] // edges whose u is this node
+	in   seqMap[struct{}] // edges whose v is this node
 }
 
 func CreateUndirected[T any]() Graph[T] {
 	return Graph[T]{
-		nodes:    []Node[T]{},
-		edges:    []Edge[T]{},
 		directed: false,
 	}
 }
 
 func CreateDirected[T any]() Graph[T] {
 	return Graph[T]{
-		nodes:    []Node[T]{},
-		edges:    []Edge[T]{},
 		directed: true,
 	}
 }
 
+// Looks up the adjacency of the given node, using Hash to find the bucket and Equal to resolve collisions.
+func (g Graph[T]) lookup(node Node[T]) (adjacency[T], bool) {
+	bucket, _ := g.index.get(node.Hash())
+	for _, adj := range bucket {
+		if adj.node.Equal(node) {
+			return adj, true
+		}
+	}
+	return adjacency[T]{}, false
+}
+
+// Checks if the given node is part of this graph.
+func (g Graph[T]) ContainsNode(node Node[T]) bool {
+	_, ok := g.lookup(node)
+	return ok
+}
+
 // Computes a new graph after adding that edge to this graph. Leaves the original graph unmodified.
 func (g Graph[T]) AddEdge(u Node[T], v Node[T], weight float64) Graph[T] {
-	edge := Edge[T]{u, v, weight}
-	newEdges := append(slices.Clone(g.edges), edge)
-	newNodes := slices.Clone(g.nodes)
+	return g.addEdge(Edge[T]{u: u, v: v, weight: weight})
+}
 
-	if !slices.Contains(newNodes, u) {
-		newNodes = append(newNodes, u)
+// Computes a new graph after adding an edge that carries a cost next to its weight. Leaves the original graph
+// unmodified.
+func (g Graph[T]) AddEdgeWithCost(u Node[T], v Node[T], weight float64, cost float64) Graph[T] {
+	return g.addEdge(Edge[T]{u: u, v: v, weight: weight, cost: cost})
+}
+
+// Stores the edge under the next free id.
+func (g Graph[T]) addEdge(edge Edge[T]) Graph[T] {
+	id := g.nextEdge
+	g.nextEdge++
+	return g.placeEdge(id, edge)
+}
+
+// Stores the edge under the given unused id and registers it in the adjacency of both of its ends.
+func (g Graph[T]) placeEdge(id int, edge Edge[T]) Graph[T] {
+	g = g.insertNode(edge.u).insertNode(edge.v)
+	g.edges = g.edges.set(id, edge)
+	g = g.updateAdjacency(edge.u, func(adj adjacency[T]) adjacency[T] {
+		adj.out = adj.out.set(id, struct{}{})
+		return adj
+	})
+	return g.updateAdjacency(edge.v, func(adj adjacency[T]) adjacency[T] {
+		adj.in = adj.in.set(id, struct{}{})
+		return adj
+	})
+}
+
+// Computes a new graph after adding that node to this graph. Adding a node that is already present has no effect.
+func (g Graph[T]) AddNode(node Node[T]) Graph[T] {
+	return g.insertNode(node)
+}
+
+// Registers the node in the index and node list if it is not already present.
+func (g Graph[T]) insertNode(node Node[T]) Graph[T] {
+	if g.ContainsNode(node) {
+		return g
+	}
+	hash := node.Hash()
+	bucket, _ := g.index.get(hash)
+	adj := adjacency[T]{node: node, id: g.nextNode}
+	g.index = g.index.set(hash, append(slices.Clip(bucket), adj))
+	g.nodes = g.nodes.set(g.nextNode, node)
+	g.nextNode++
+	return g
+}
+
+// Replaces the adjacency of an already registered node with an updated copy.
+func (g Graph[T]) updateAdjacency(node Node[T], update func(adjacency[T]) adjacency[T]) Graph[T] {
+	hash := node.Hash()
+	bucket, _ := g.index.get(hash)
+	bucket = slices.Clone(bucket)
+	for idx := range bucket {
+		if bucket[idx].node.Equal(node) {
+			bucket[idx] = update(bucket[idx])
+		}
 	}
-	if !slices.Contains(newNodes, v) {
-		newNodes = append(newNodes, v)
+	g.index = g.index.set(hash, bucket)
+	return g
+}
+
+// Removes the edge with the given id along with its entries in the adjacency of both of its ends. The nodes themselves
+// stay in the graph.
+func (g Graph[T]) deleteEdge(id int) Graph[T] {
+	edge, ok := g.edges.get(id)
+	if !ok {
+		return g
+	}
+	g.edges = g.edges.delete(id)
+	g = g.updateAdjacency(edge.u, func(adj adjacency[T]) adjacency[T] {
+		adj.out = adj.out.delete(id)
+		return adj
+	})
+	return g.updateAdjacency(edge.v, func(adj adjacency[T]) adjacency[T] {
+		adj.in = adj.in.delete(id)
+		return adj
+	})
+}
+
+// Finds the ids of every edge from u to v in ascending order. Undirected edges match in either orientation.
+func (g Graph[T]) edgeIDsBetween(u Node[T], v Node[T]) []int {
+	ids := []int{}
+	adj, ok := g.lookup(u)
+	if !ok {
+		return ids
+	}
+	for id := range adj.out.all() {
+		if g.edge(id).v.Equal(v) {
+			ids = append(ids, id)
+		}
 	}
+	if !g.directed {
+		for id := range adj.in.all() {
+			if g.edge(id).u.Equal(v) && !adj.out.has(id) {
+				ids = append(ids, id)
+			}
+		}
+		slices.Sort(ids)
+	}
+	return ids
+}
 
-	return Graph[T]{
-		edges:    newEdges,
-		nodes:    newNodes,
-		directed: g.directed,
+// Computes a new graph without the earliest added edge from u to v. In an undirected graph the edge may have been added
+// in either orientation. Leaves the graph unchanged if there is no such edge.
+func (g Graph[T]) RemoveEdge(u Node[T], v Node[T]) Graph[T] {
+	if ids := g.edgeIDsBetween(u, v); len(ids) > 0 {
+		return g.deleteEdge(ids[0])
 	}
+	return g
 }
 
-func (g Graph[T]) AddNode(node Node[T]) Graph[T] {
-	newNodes := append(slices.Clone(g.nodes), node)
-	return Graph[T]{
-		edges:    g.edges,
-		nodes:    newNodes,
-		directed: g.directed,
+// Computes a new graph without any of the edges from u to v. In an undirected graph this also removes edges that were
+// added from v to u.
+func (g Graph[T]) RemoveEdgesBetween(u Node[T], v Node[T]) Graph[T] {
+	for _, id := range g.edgeIDsBetween(u, v) {
+		g = g.deleteEdge(id)
+	}
+	return g
+}
+
+// Computes a new graph without the given node and every edge that starts or ends at it.
+func (g Graph[T]) RemoveNode(node Node[T]) Graph[T] {
+	adj, ok := g.lookup(node)
+	if !ok {
+		return g
+	}
+	for id := range adj.out.all() {
+		g = g.deleteEdge(id)
+	}
+	// Self loops were already removed together with the outgoing edges.
+	for id := range adj.in.all() {
+		g = g.deleteEdge(id)
+	}
+	g.nodes = g.nodes.delete(adj.id)
+	hash := node.Hash()
+	bucket, _ := g.index.get(hash)
+	bucket = slices.DeleteFunc(slices.Clone(bucket), func(other adjacency[T]) bool {
+		return other.node.Equal(node)
+	})
+	if len(bucket) == 0 {
+		g.index = g.index.delete(hash)
+	} else {
+		g.index = g.index.set(hash, bucket)
+	}
+	return g
+}
+
+// Computes a new graph where the edge between u and v is contracted, merging v into u. Every edge between the two
+// nodes disappears and every other edge of v is redirected to u, keeping its weight and its place in the edge order.
+// Parallel edges created this way are kept. Leaves the graph unchanged if there is no edge between u and v.
+func (g Graph[T]) ContractEdge(u Node[T], v Node[T]) Graph[T] {
+	if u.Equal(v) || len(g.edgeIDsBetween(u, v)) == 0 {
+		return g
+	}
+	// Edges back from v to u would turn into self loops as well.
+	g = g.RemoveEdgesBetween(u, v).RemoveEdgesBetween(v, u)
+	adj, _ := g.lookup(v)
+	redirected := map[int]Edge[T]{}
+	for id := range adj.out.all() {
+		edge := g.edge(id)
+		edge.u = u
+		if edge.v.Equal(v) {
+			edge.v = u
+		}
+		redirected[id] = edge
 	}
+	for id := range adj.in.all() {
+		if _, ok := redirected[id]; !ok {
+			edge := g.edge(id)
+			edge.v = u
+			redirected[id] = edge
+		}
+	}
+	g = g.RemoveNode(v)
+	for _, id := range slices.Sorted(maps.Keys(redirected)) {
+		g = g.placeEdge(id, redirected[id])
+	}
+	return g
 }
 
 /*
 Creates a new graph that interprets all edges as directed. I.e. makes all edges e <-> v to u -> v
 */
 func (g Graph[T]) ToDirected() Graph[T] {
-	return Graph[T]{
-		edges:    g.edges,
-		nodes:    g.nodes,
-		directed: true,
-	}
+	g.directed = true
+	return g
 }
 
 func (g Graph[T]) GetNumberOfEdges() int {
-	return len(g.edges)
+	return g.edges.len()
+}
+
+// Returns every edge of this graph in the order they were added.
+func (g Graph[T]) GetEdges() []Edge[T] {
+	return g.edges.values()
 }
 
 // Reverses this edge, has no effect on an undirected edge
 func (e Edge[T]) reverse() Edge[T] {
-	return Edge[T]{
-		u:      e.v,
-		v:      e.u,
-		weight: e.weight,
-	}
+	e.u, e.v = e.v, e.u
+	return e
+}
+
+// Creates a standalone edge from u to v, e.g. for batch inserts through a GraphBuilder.
+func CreateEdge[T any](u Node[T], v Node[T], weight float64) Edge[T] {
+	return Edge[T]{u: u, v: v, weight: weight}
+}
+
+// Returns a copy of this edge with the given cost.
+func (e Edge[T]) WithCost(cost float64) Edge[T] {
+	e.cost = cost
+	return e
 }
 
 func (e Edge[T]) V() Node[T] {
@@ -104,31 +297,80 @@ func (e Edge[T]) U() Node[T] {
 	return e.u
 }
 
+func (e Edge[T]) Weight() float64 {
+	return e.weight
+}
+
+func (e Edge[T]) Cost() float64 {
+	return e.cost
+}
+
+// Streams the edges with the given ids, merging both id sets in ascending order. Edges found in primary are yielded as
+// is, edges only found in secondary are reversed. An undirected self loop shows up in both sets but is only yielded
+// once.
+func (g Graph[T]) incidentSeq(primarySet, secondarySet seqMap[struct{}]) iter.Seq[Edge[T]] {
+	return func(yield func(Edge[T]) bool) {
+		primarySet.merge(secondarySet, func(id int, inPrimary bool, _ bool) bool {
+			if inPrimary {
+				return yield(g.edge(id))
+			}
+			return yield(g.edge(id).reverse())
+		})
+	}
+}
+
+// Collects the edges incidentSeq streams.
+func (g Graph[T]) mergeIncident(primarySet, secondarySet seqMap[struct{}]) []Edge[T] {
+	returnEdges := make([]Edge[T], 0, primarySet.len()+secondarySet.len())
+	for edge := range g.incidentSeq(primarySet, secondarySet) {
+		returnEdges = append(returnEdges, edge)
+	}
+	return returnEdges
+}
+
+func (g Graph[T]) edge(id int) Edge[T] {
+	edge, _ := g.edges.get(id)
+	return edge
+}
+
+// Counts the edges mergeIncident would collect without materializing them.
+func countIncident(primarySet, secondarySet seqMap[struct{}]) int {
+	count := primarySet.len() + secondarySet.len()
+	if secondarySet.len() < primarySet.len() {
+		primarySet, secondarySet = secondarySet, primarySet
+	}
+	// An undirected self loop shows up in both sets but only counts once.
+	for id := range primarySet.all() {
+		if secondarySet.has(id) {
+			count--
+		}
+	}
+	return count
+}
+
 // Finds the edges that lead to the given node. Checks using the given equality function on the graph
 func (g Graph[T]) FindEdgesThatLeadTo(source Node[T]) []Edge[T] {
-	returnEdges := []Edge[T]{}
-	for _, e := range g.edges {
-		if g.directed && source.Equal(e.v) || (!g.directed && source.Equal(e.v)) {
-			returnEdges = append(returnEdges, e)
-		} else if !g.directed && source.Equal(e.u) {
-			// We are going to reverse the direction of the edge
-			returnEdges = append(returnEdges, e.reverse())
-		}
+	adj, ok := g.lookup(source)
+	if !ok {
+		return []Edge[T]{}
 	}
-	return returnEdges
+	if g.directed {
+		return g.mergeIncident(adj.in, seqMap[struct{}]{})
+	}
+	// Edges that leave the source in an undirected graph are reversed so that they lead to it.
+	return g.mergeIncident(adj.in, adj.out)
 }
 
 // Finds the edges that lead from the given node. Checks using the given equality function on the graph
 func (g Graph[T]) FindEdgesThatLeadFrom(source Node[T]) []Edge[T] {
-	returnEdges := []Edge[T]{}
-	for _, e := range g.edges {
-		if g.directed && source.Equal(e.u) || (!g.directed && source.Equal(e.u)) {
-			returnEdges = append(returnEdges, e)
-		} else if !g.directed && source.Equal(e.v) {
-			returnEdges = append(returnEdges, e.reverse())
-		}
+	adj, ok := g.lookup(source)
+	if !ok {
+		return []Edge[T]{}
 	}
-	return returnEdges
+	if g.directed {
+		return g.mergeIncident(adj.out, seqMap[struct{}]{})
+	}
+	return g.mergeIncident(adj.out, adj.in)
 }
 
 // Checks if this graph is directed or undirected.
@@ -136,92 +378,44 @@ func (g Graph[T]) IsDirectedGraph() bool {
 	return g.directed
 }
 
+// Finds every node reachable from the source over a single edge. Each neighbor is only reported once, in the order its
+// first edge was added.
 func (g Graph[T]) FindNeighboringNodes(source Node[T]) []Node[T] {
-	neighbors := []*Node[T]{}
-	// Now check each node which is reachable to this node
-	edgesThatLeadFrom := g.FindEdgesThatLeadFrom(source)
-	edgesThatLeadTo := g.FindEdgesThatLeadTo(source)
-	for _, edge := range edgesThatLeadFrom {
-		neighbor := edge.v
-		if !slices.Contains(neighbors, &neighbor) {
-			neighbors = append(neighbors, &neighbor)
-		}
-	}
-	// Another check, every to edge is also a neighbor
-	if !g.IsDirectedGraph() {
-		for _, edge := range edgesThatLeadTo {
-			neighbor := edge.u
-			if !slices.Contains(neighbors, &neighbor) {
-				neighbors = append(neighbors, &neighbor)
-			}
+	neighbors := []Node[T]{}
+	seen := newNodeSet[T]()
+	for _, edge := range g.FindEdgesThatLeadFrom(source) {
+		if !seen.has(edge.v) {
+			seen.add(edge.v)
+			neighbors = append(neighbors, edge.v)
 		}
 	}
-	newNeighbors := []Node[T]{}
-	for _, n := range neighbors {
-		newNeighbors = append(newNeighbors, *n)
-	}
-	return newNeighbors
+	return neighbors
 }
 
 // Performs a DFS on this graph from the given source, returns a list of nodes that were visited by DFS in accordance to
-// the graph comparator
+// the graph comparator. Built on DFSForest, so deep graphs do not overflow the stack. A source that is not part of
+// this graph has no neighbors to visit, so it is the only node visited.
 func (g Graph[T]) DFS(source Node[T]) []Node[T] {
-	var dfsImpl func(src Node[T], visited *[]Node[T], acc *[]Node[T])
-	dfsImpl = func(src Node[T], visited *[]Node[T], acc *[]Node[T]) {
-		// Mark the current node as visited
-		*visited = append(*visited, src)
-		neighbors := g.FindNeighboringNodes(src)
-		slices.SortStableFunc(neighbors, func(n1 Node[T], n2 Node[T]) int {
-			return n1.Compare(n2)
-		})
-		for _, neighbor := range neighbors {
-			if !slices.ContainsFunc(*visited, func(n Node[T]) bool { return neighbor.Equal(n) }) {
-				*acc = append(*acc, neighbor)
-				dfsImpl(neighbor, visited, acc)
-			}
-		}
+	forest, err := g.DFSForest(source)
+	if err != nil {
+		return []Node[T]{source}
 	}
-	acc := []Node[T]{source}
-	visited := []Node[T]{}
-	dfsImpl(source, &visited, &acc)
-	return acc
+	return forest.Preorder()
 }
 
-// Performs a BFS on this graph from the given source. Returns a list of nodes that were visited by DFS in accordance to
-// the graph comparator
+// Performs a BFS on this graph from the given source. Returns a list of nodes in the order BFS visited them, level by
+// level and in accordance to the graph comparator within each level. See BFSTree for distances and parents. A source
+// that is not part of this graph has no neighbors to visit, so it is the only node visited.
 func (g Graph[T]) BFS(source Node[T]) []Node[T] {
-	queue := []Node[T]{}
-	visited := []Node[T]{}
-	bfs := []Node[T]{source}
-	queue = append(queue, source)
-	// While the queue is not empty
-	for len(queue) != 0 {
-		// Pop the last neighbor in the queue
-		src := queue[len(queue)-1]
-		queue = queue[:len(queue)-1]
-		visited = append(visited, src)
-		neighbors := g.FindNeighboringNodes(src)
-		slices.SortStableFunc(neighbors, func(n1 Node[T], n2 Node[T]) int {
-			return n1.Compare(n2)
-		})
-		for _, neighbor := range neighbors {
-			// If we haven't visited this neighbor
-			if !slices.ContainsFunc(visited, func(n Node[T]) bool { return neighbor.Equal(n) }) {
-				bfs = append(bfs, neighbor)
-				visited = append(visited, neighbor)
-				queue = append(queue, neighbor)
-			}
-		}
-	}
-	return bfs
+	return slices.Collect(g.BFSSeq(source))
 }
 
 func (g Graph[T]) GetNodes() []Node[T] {
-	return slices.Clone(g.nodes)
+	return g.nodes.values()
 }
 
 func (g Graph[T]) GetNumberOfNodes() int {
-	return len(g.nodes)
+	return g.nodes.len()
 }
 
 // Returns a new graph with all nodes of type U instead of type T. To make the resulting graph valid, one must also pass
@@ -231,53 +425,59 @@ func MapGraph[T any, U any](
 	g Graph[T],
 	mapFn func(Node[T]) Node[U],
 ) Graph[U] {
-	newEdges := []Edge[U]{}
-	newNodes := []Node[U]{}
-	for _, e := range g.edges {
-		newU := mapFn(e.u)
-		newV := mapFn(e.v)
-		newEdge := Edge[U]{
-			u:      newU,
-			v:      newV,
-			weight: e.weight,
-		}
-		newEdges = append(newEdges, newEdge)
-		newNodes = append(newNodes, newU)
-		newNodes = append(newNodes, newV)
+	newGraph := Graph[U]{directed: g.directed}
+	for _, e := range g.edges.all() {
+		newGraph = newGraph.addEdge(Edge[U]{u: mapFn(e.u), v: mapFn(e.v), weight: e.weight, cost: e.cost})
 	}
-	return Graph[U]{
-		edges:    newEdges,
-		nodes:    newNodes,
-		directed: g.directed,
+	// Nodes without any edges still belong to the mapped graph.
+	for _, n := range g.nodes.all() {
+		newGraph = newGraph.AddNode(mapFn(n))
 	}
+	return newGraph
 }
 
-// Filters the edges from this graph that both nodes on the edge must meet
+// Filters the edges from this graph that both nodes on the edge must meet. The filtered graph keeps the direction of
+// this graph and all of its nodes, including the ones left without edges, like MapGraph.
 func FilterGraph[T any](graph Graph[T], filterFn func(Edge[T]) bool) Graph[T] {
-	newEdges := []Edge[T]{}
-	for _, edge := range graph.edges {
+	newGraph := Graph[T]{directed: graph.directed}
+	for _, edge := range graph.edges.all() {
 		if filterFn(edge) {
-			newEdges = append(newEdges, edge)
+			newGraph = newGraph.addEdge(edge)
 		}
 	}
-	return Graph[T]{
-		edges: newEdges,
+	for _, node := range graph.nodes.all() {
+		newGraph = newGraph.AddNode(node)
 	}
+	return newGraph
 }
 
 // Finds the "in-degree" or the number of edges that lead to this source node.
 func (g Graph[T]) FindInDegree(source Node[T]) int {
-	return len(g.FindEdgesThatLeadTo(source))
+	adj, ok := g.lookup(source)
+	if !ok {
+		return 0
+	}
+	if g.directed {
+		return adj.in.len()
+	}
+	return countIncident(adj.in, adj.out)
 }
 
 func (g Graph[T]) FindOutDegree(source Node[T]) int {
-	return len(g.FindEdgesThatLeadFrom(source))
+	adj, ok := g.lookup(source)
+	if !ok {
+		return 0
+	}
+	if g.directed {
+		return adj.out.len()
+	}
+	return countIncident(adj.out, adj.in)
 }
 
 // Returns all the nodes with indegrees of 0
 func (g Graph[T]) GetRootNodes() []Node[T] {
 	roots := []Node[T]{}
-	for _, node := range g.GetNodes() {
+	for _, node := range g.nodes.all() {
 		if g.FindInDegree(node) == 0 {
 			roots = append(roots, node)
 		}
@@ -288,7 +488,7 @@ func (g Graph[T]) GetRootNodes() []Node[T] {
 // Returns all the nodes with out degrees of 0
 func (g Graph[T]) GetLeafNodes() []Node[T] {
 	roots := []Node[T]{}
-	for _, node := range g.GetNodes() {
+	for _, node := range g.nodes.all() {
 		if g.FindOutDegree(node) == 0 {
 			roots = append(roots, node)
 		}
@@ -296,38 +496,125 @@ func (g Graph[T]) GetLeafNodes() []Node[T] {
 	return roots
 }
 
-// Determines if this graph contains a cycle.
-func (g Graph[T]) ContainsCycle() bool {
-	if !g.directed {
-		panic("Cannot check if an undirected graph has a cycle")
-	}
-	var isCyclicImpl func(idx int, node Node[T], visited, stack []bool) bool
-	isCyclicImpl = func(idx int, node Node[T], visited, stack []bool) bool {
-		if stack[idx] {
+// Finds the nodes of one cycle in this graph, or nil if there is none. Every returned node leads to the next one and
+// the last node leads back to the first. In an undirected graph a single edge does not make a cycle, but a self loop
+// or two parallel edges between the same nodes do.
+func (g Graph[T]) FindCycle() []Node[T] {
+	if g.directed {
+		return g.findDirectedCycle()
+	}
+	return g.findUndirectedCycle()
+}
+
+// Finds the nodes of one cycle in this undirected graph, or nil if there is none. The search walks edges rather than
+// nodes and never goes back over the edge it arrived through, so a single edge is not mistaken for a cycle while
+// parallel edges still are.
+func (g Graph[T]) findUndirectedCycle() []Node[T] {
+	type frame struct {
+		node       Node[T]
+		edges      []int
+		next       int
+		parentEdge int
+	}
+	visited := newNodeSet[T]()
+	incident := func(node Node[T]) []int {
+		adj, _ := g.lookup(node)
+		ids := make([]int, 0, adj.out.len()+adj.in.len())
+		// Self loops are both an out and an in edge of the node but only show up once.
+		adj.out.merge(adj.in, func(id int, _ bool, _ bool) bool {
+			ids = append(ids, id)
 			return true
+		})
+		return ids
+	}
+	for _, start := range g.nodes.all() {
+		if visited.has(start) {
+			continue
 		}
-		if visited[idx] {
-			return false
-		}
-		stack[idx] = true
-		visited[idx] = true
-		for newIdx, neighbor := range g.FindNeighboringNodes(node) {
-			if isCyclicImpl(newIdx, neighbor, visited, stack) {
-				return true
+		visited.add(start)
+		stack := []frame{{node: start, edges: incident(start), parentEdge: -1}}
+		for len(stack) > 0 {
+			top := &stack[len(stack)-1]
+			if top.next == len(top.edges) {
+				stack = stack[:len(stack)-1]
+				continue
 			}
+			id := top.edges[top.next]
+			top.next++
+			if id == top.parentEdge {
+				continue
+			}
+			edge := g.edge(id)
+			neighbor := edge.v
+			if !edge.u.Equal(top.node) {
+				neighbor = edge.u
+			}
+			if !visited.has(neighbor) {
+				visited.add(neighbor)
+				stack = append(stack, frame{node: neighbor, edges: incident(neighbor), parentEdge: id})
+				continue
+			}
+			// Every visited node reachable over an unused edge is still on the stack, so this edge closes a cycle.
+			start := slices.IndexFunc(stack, func(f frame) bool { return f.node.Equal(neighbor) })
+			cycle := []Node[T]{}
+			for _, f := range stack[start:] {
+				cycle = append(cycle, f.node)
+			}
+			return cycle
 		}
-		stack[idx] = true
-		return false
-	}
-	nodes := g.GetNodes()
-	visited := make([]bool, len(nodes))
-	stack := make([]bool, len(nodes))
-	for idx, node := range nodes {
-		if !visited[idx] && isCyclicImpl(idx, node, visited, stack) {
-			return true
+	}
+	return nil
+}
+
+// Finds the nodes of one cycle in this directed graph, or nil if there is none. Uses an explicit stack so deep graphs
+// do not overflow the call stack.
+func (g Graph[T]) findDirectedCycle() []Node[T] {
+	type frame struct {
+		node      Node[T]
+		neighbors []Node[T]
+		next      int
+	}
+	const (
+		onStack = iota
+		finished
+	)
+	state := newNodeMap[T, int]()
+	for _, start := range g.nodes.all() {
+		if state.has(start) {
+			continue
+		}
+		state.put(start, onStack)
+		stack := []frame{{node: start, neighbors: g.FindNeighboringNodes(start)}}
+		for len(stack) > 0 {
+			top := &stack[len(stack)-1]
+			if top.next == len(top.neighbors) {
+				state.put(top.node, finished)
+				stack = stack[:len(stack)-1]
+				continue
+			}
+			neighbor := top.neighbors[top.next]
+			top.next++
+			neighborState, seen := state.get(neighbor)
+			if !seen {
+				state.put(neighbor, onStack)
+				stack = append(stack, frame{node: neighbor, neighbors: g.FindNeighboringNodes(neighbor)})
+			} else if neighborState == onStack {
+				// The neighbor is still on the stack, so the stack from it up to here closes a cycle.
+				start := slices.IndexFunc(stack, func(f frame) bool { return f.node.Equal(neighbor) })
+				cycle := []Node[T]{}
+				for _, f := range stack[start:] {
+					cycle = append(cycle, f.node)
+				}
+				return cycle
+			}
 		}
 	}
-	return false
+	return nil
+}
+
+// Determines if this graph contains a cycle.
+func (g Graph[T]) ContainsCycle() bool {
+	return g.FindCycle() != nil
 }
 
 // Returns a mapping of each nodes neighbors with the supplied hash function used as the key.
@@ -345,60 +632,41 @@ func (g Graph[T]) IsDAG() bool {
 	return g.IsDirectedGraph() && !g.ContainsCycle()
 }
 
-// Returns all possible topological sorts on this graph. This implementation relies on a hash function that does not
-// have any collision detection
+// Returns all possible topological sorts on this graph.
 func (g Graph[T]) GetAllTopologicalSorts() [][]Node[T] {
-	if !g.IsDAG() {
-		panic("The following graph must be a DAG")
-	}
-	var backtrack func(visited map[int]bool, indeg map[int]int, adjMap map[int][]Node[T], ordering *[]Node[T], allOrderings *[][]Node[T])
-	backtrack = func(visited map[int]bool, indeg map[int]int, adjMap map[int][]Node[T], ordering *[]Node[T], allOrderings *[][]Node[T]) {
-		for _, node := range g.GetNodes() {
-			hash := node.Hash()
-			if indeg[hash] != 0 || visited[hash] {
-				continue
-			}
-			neighbors := adjMap[hash]
-			// Reduce the indegree on each neighbor
-			for _, neighbor := range neighbors {
-				indeg[neighbor.Hash()] = indeg[neighbor.Hash()] - 1
-			}
-			*ordering = append(*ordering, node)
-			visited[hash] = true
-			backtrack(visited, indeg, adjMap, ordering, allOrderings)
-			for _, neighbor := range neighbors {
-				indeg[neighbor.Hash()] = indeg[neighbor.Hash()] + 1
-			}
-			*ordering = (*ordering)[:len(*ordering)-1] // pop
-			visited[hash] = false
-		}
-		if len(*ordering) == len(g.GetNodes()) {
-			*allOrderings = append(*allOrderings, slices.Clone(*ordering))
-		}
+	allTopologicalOrderings, err := g.TopologicalSorts()
+	if err != nil {
+		panic(err)
+	}
+	return allTopologicalOrderings
+}
+
+// Returns all possible topological sorts on this graph. Fails with ErrUndirectedGraph on an undirected graph and with
+// a NotDAGError holding the offending cycle if the graph has one. The number of sorts grows factorially with the number
+// of independent nodes, see AllTopologicalSorts for a lazy version.
+func (g Graph[T]) TopologicalSorts() ([][]Node[T], error) {
+	sorts, err := g.AllTopologicalSorts()
+	if err != nil {
+		return nil, err
 	}
-	adjMap := g.ToAdjacencyNodeMap()
-	visitedSet := map[int]bool{}
-	indegrees := map[int]int{}
-	topologicalOrdering := []Node[T]{}
 	allTopologicalOrderings := [][]Node[T]{}
-	nodes := g.GetNodes()
-	for _, n := range nodes {
-		visitedSet[n.Hash()] = false
-		indegrees[n.Hash()] = g.FindInDegree(n)
+	for ordering := range sorts {
+		allTopologicalOrderings = append(allTopologicalOrderings, ordering)
 	}
-	backtrack(visitedSet, indegrees, adjMap, &topologicalOrdering, &allTopologicalOrderings)
-	return allTopologicalOrderings
+	return allTopologicalOrderings, nil
 }
 
-func (g Graph[T]) hasNegativeEdgeWeights() bool {
-	for _, edge := range g.edges {
+// Finds the earliest added edge with a negative weight.
+func (g Graph[T]) findNegativeEdge() (Edge[T], bool) {
+	for _, edge := range g.edges.all() {
 		if edge.weight < 0 {
-			return true
+			return edge, true
 		}
 	}
-	return false
+	return Edge[T]{}, false
 }
 
+// A min heap of nodes ordered by Node.Compare, used to break ties deterministically.
 type nodeMinHeap[T any] struct {
 	heap []Node[T]
 }
@@ -425,57 +693,29 @@ func (g Graph[T]) ToAdjacencyEdgeMap() map[int][]Edge[T] {
 	return adj
 }
 
+// Finds *a* single shortest path from the root to every node it can reach, keyed by the Hash of the destination. A root
+// that is not part of this graph reaches nothing. Panics if the graph has a negative edge weight, see ShortestPaths.
 func (g Graph[T]) Dijkstras(root Node[T]) map[int][]Node[T] {
-	if g.hasNegativeEdgeWeights() {
-		panic("Cannot run Dijkstras with negative edge weights")
+	allShortestPaths, err := g.ShortestPaths(root)
+	if errors.Is(err, ErrNegativeWeight) {
+		panic(err)
 	}
-	allShortestPaths := map[int][]Node[T]{}
-	predecessors := map[int]*Node[T]{}
-	dist := map[int]float64{}
-	minHeap := &nodeMinHeap[T]{heap: []Node[T]{root}}
-	visited := map[int]bool{}
-	adj_map := g.ToAdjacencyEdgeMap()
-	heap.Init(minHeap)
-	// Initialize all distance mappings to infinity
-	for _, node := range g.GetNodes() {
-		dist[node.Hash()] = math.Inf(1)
-		predecessors[node.Hash()] = nil
-		visited[node.Hash()] = false
-	}
-	dist[root.Hash()] = 0
-
-	for minHeap.Len() > 0 {
-		curr := heap.Pop(minHeap).(Node[T])
-		if visited[curr.Hash()] {
-			continue
-		}
-		visited[curr.Hash()] = true
-		neighbors := adj_map[curr.Hash()]
-
-		for _, neighborEdge := range neighbors {
-			neighbor := neighborEdge.v
-			weight := neighborEdge.weight
-			newWeight := weight + dist[curr.Hash()]
-			if newWeight < dist[neighbor.Hash()] {
-				dist[neighbor.Hash()] = newWeight
-				predecessors[neighbor.Hash()] = &curr
-				heap.Push(minHeap, neighbor)
-			}
-		}
+	if err != nil {
+		return map[int][]Node[T]{}
 	}
+	return allShortestPaths
+}
 
-	// Now we build all shortest paths to each node.
-	for _, node := range g.GetNodes() {
-		if math.IsInf(dist[node.Hash()], 1) {
-			continue
-		}
-		path := []Node[T]{}
-		for curr := node; !curr.Equal(root); curr = *predecessors[curr.Hash()] {
-			path = append(path, curr)
-		}
-		path = append(path, root)
-		slices.Reverse(path)
-		allShortestPaths[node.Hash()] = path
+// Runs Dijkstras from the given root, see Dijkstras and DijkstrasTree. Fails with a NodeNotFoundError if the root is
+// not part of this graph and with a NegativeWeightError holding the first negative edge if there is one.
+func (g Graph[T]) ShortestPaths(root Node[T]) (map[int][]Node[T], error) {
+	tree, err := g.DijkstrasTree(root)
+	if err != nil {
+		return nil, err
 	}
-	return allShortestPaths
+	allShortestPaths := map[int][]Node[T]{}
+	for _, node := range tree.ReachableNodes() {
+		allShortestPaths[node.Hash()], _ = tree.PathTo(node)
+	}
+	return allShortestPaths, nil
 }
@@ -257,8 +257,9 @@ func (g Graph[T]) renderGraph(gtx layout.Context, widgets *widgets) layout.Dimen
 		return colorBox(gtx, gtx.Constraints.Max, white)
 	}
 	var graph layout.Widget = func(gtx layout.Context) layout.Dimensions {
-		return widgets.graphRender.Layout(gtx, len(g.edges), func(gtx layout.Context, index int) layout.Dimensions {
-			edgeToRender := g.edges[index]
+		edges := g.GetEdges()
+		return widgets.graphRender.Layout(gtx, len(edges), func(gtx layout.Context, index int) layout.Dimensions {
+			edgeToRender := edges[index]
 			return edgeToRender.renderEdge(gtx, widgets)
 		})
 	}
@@ -296,7 +297,7 @@ func (g Graph[T]) drawLayout(gtx layout.Context, widgets *widgets) {
 				"View all Edges",
 				widgets.edgeButton,
 				widgets,
-				g.edges,
+				g.GetEdges(),
 				widgets.modalEdgeList,
 			)
 		} else {
@@ -100,11 +100,11 @@ func TestABCGraph(t *testing.T) {
 	// Order of DFS should be b, c, a
 	assert.Equal(t, []graph.Node[string]{StringNode{"C"}, StringNode{"A"}, StringNode{"B"}}, c_dfs)
 
-	for _, starting_node := range []graph.Node[string]{StringNode{"A"}, StringNode{"B"}, StringNode{"C"}} {
-		dfs := abc.DFS(starting_node)
-		bfs := abc.BFS(starting_node)
-		assert.Equal(t, dfs, bfs)
-	}
+	// With a second way out of A the traversals part ways, DFS follows B down to C before it gets to D.
+	a, b, c, d := StringNode{"A"}, StringNode{"B"}, StringNode{"C"}, StringNode{"D"}
+	abcd := abc.AddEdge(a, d, 0)
+	assert.Equal(t, []graph.Node[string]{a, b, c, d}, abcd.DFS(a))
+	assert.Equal(t, []graph.Node[string]{a, b, d, c}, abcd.BFS(a))
 
 	// This graph has a cycle so no possible way to topologically sort this graph.
 	assert.Panics(t, func() { abc.GetAllTopologicalSorts() })
@@ -144,3 +144,274 @@ func TestAdjacencyMaps(t *testing.T) {
 		assert.Empty(t, noEdgeAdjNodeMap[node.Hash()])
 	}
 }
+
+// Every CollidingNode hashes to the same bucket, so lookups must fall back to Equal.
+type CollidingNode struct {
+	val int
+}
+
+func (n CollidingNode) Compare(node graph.Node[int]) int {
+	return n.val - node.Val()
+}
+
+func (n CollidingNode) Equal(node graph.Node[int]) bool {
+	return n.val == node.Val()
+}
+
+func (n CollidingNode) Hash() int {
+	return 7
+}
+
+func (n CollidingNode) Val() int {
+	return n.val
+}
+
+func TestAdjacencyIndex(t *testing.T) {
+	g := graph.CreateUndirected[int]()
+	g = g.AddEdge(NumberNode{1}, NumberNode{2}, 1)
+	g = g.AddEdge(NumberNode{3}, NumberNode{1}, 2)
+	g = g.AddEdge(NumberNode{1}, NumberNode{2}, 3)
+
+	// Undirected edges lead from both of their ends, reversed where needed, in the order they were added.
+	from := g.FindEdgesThatLeadFrom(NumberNode{1})
+	assert.Len(t, from, 3)
+	assert.Equal(t, graph.Node[int](NumberNode{2}), from[0].V())
+	assert.Equal(t, graph.Node[int](NumberNode{3}), from[1].V())
+	assert.Equal(t, graph.Node[int](NumberNode{1}), from[1].U())
+	assert.Equal(t, 3, g.FindInDegree(NumberNode{1}))
+	assert.Equal(t, 3, g.FindOutDegree(NumberNode{1}))
+	// Parallel edges only contribute a single neighbor.
+	assert.Equal(t, []graph.Node[int]{NumberNode{2}, NumberNode{3}}, g.FindNeighboringNodes(NumberNode{1}))
+	assert.Equal(t, 3, g.GetNumberOfNodes())
+
+	directed := g.ToDirected()
+	assert.Equal(t, 2, directed.FindOutDegree(NumberNode{1}))
+	assert.Equal(t, 1, directed.FindInDegree(NumberNode{1}))
+	assert.Empty(t, directed.FindEdgesThatLeadFrom(NumberNode{2}))
+	assert.Empty(t, directed.FindEdgesThatLeadFrom(NumberNode{42}))
+
+	// The original graph is left untouched by later additions.
+	bigger := g.AddEdge(NumberNode{2}, NumberNode{4}, 1)
+	assert.Equal(t, 3, g.GetNumberOfNodes())
+	assert.Equal(t, 4, bigger.GetNumberOfNodes())
+	assert.False(t, g.ContainsNode(NumberNode{4}))
+	assert.True(t, bigger.ContainsNode(NumberNode{4}))
+}
+
+func TestAdjacencyIndexDistantEdges(t *testing.T) {
+	// The first edge of 0 and the ones added much later sit in id sets of different heights.
+	g := graph.CreateUndirected[int]().AddEdge(NumberNode{0}, NumberNode{1}, 0)
+	for i := range 2000 {
+		g = g.AddEdge(NumberNode{i + 2}, NumberNode{i + 3}, 0)
+	}
+	g = g.AddEdge(NumberNode{5}, NumberNode{0}, 1).AddEdge(NumberNode{0}, NumberNode{0}, 2)
+	assert.Equal(t, []graph.Edge[int]{
+		graph.CreateEdge[int](NumberNode{0}, NumberNode{1}, 0),
+		graph.CreateEdge[int](NumberNode{0}, NumberNode{5}, 1),
+		graph.CreateEdge[int](NumberNode{0}, NumberNode{0}, 2),
+	}, g.FindEdgesThatLeadFrom(NumberNode{0}))
+	assert.Equal(t, 3, g.FindOutDegree(NumberNode{0}))
+	assert.Equal(t, []graph.Node[int]{NumberNode{1}, NumberNode{5}, NumberNode{0}}, g.FindNeighboringNodes(NumberNode{0}))
+	assert.Equal(t, []graph.Node[int]{NumberNode{0}}, g.FindCycle())
+	assert.False(t, g.RemoveEdge(NumberNode{0}, NumberNode{0}).ContainsCycle())
+}
+
+func TestAdjacencyIndexHashCollisions(t *testing.T) {
+	g := graph.CreateDirected[int]()
+	g = g.AddEdge(CollidingNode{1}, CollidingNode{2}, 0)
+	g = g.AddEdge(CollidingNode{2}, CollidingNode{3}, 0)
+	g = g.AddNode(CollidingNode{3})
+
+	assert.Equal(t, 3, g.GetNumberOfNodes())
+	assert.Equal(t, []graph.Node[int]{CollidingNode{2}}, g.FindNeighboringNodes(CollidingNode{1}))
+	assert.Equal(t, []graph.Node[int]{CollidingNode{3}}, g.FindNeighboringNodes(CollidingNode{2}))
+	assert.Equal(t, []graph.Node[int]{CollidingNode{1}}, g.GetRootNodes())
+	assert.Equal(t, []graph.Node[int]{CollidingNode{3}}, g.GetLeafNodes())
+	assert.Equal(t, []graph.Node[int]{CollidingNode{1}, CollidingNode{2}, CollidingNode{3}}, g.DFS(CollidingNode{1}))
+}
+
+func TestPersistentVersions(t *testing.T) {
+	g := graph.CreateDirected[int]()
+	versions := []graph.Graph[int]{}
+	for i := range 2000 {
+		if i%500 == 0 {
+			versions = append(versions, g)
+		}
+		g = g.AddEdge(NumberNode{i}, NumberNode{i + 1}, float64(i))
+	}
+	assert.Equal(t, 2000, g.GetNumberOfEdges())
+	assert.Equal(t, 2001, g.GetNumberOfNodes())
+	// Every earlier version still sees exactly the edges it was built with.
+	for idx, version := range versions {
+		assert.Equal(t, idx*500, version.GetNumberOfEdges())
+	}
+
+	// Two graphs branching off the same version do not see each other's edges.
+	base := versions[1]
+	left := base.AddEdge(NumberNode{0}, NumberNode{-1}, 0)
+	right := base.AddEdge(NumberNode{0}, NumberNode{-2}, 0)
+	assert.Equal(t, []graph.Node[int]{NumberNode{1}, NumberNode{-1}}, left.FindNeighboringNodes(NumberNode{0}))
+	assert.Equal(t, []graph.Node[int]{NumberNode{1}, NumberNode{-2}}, right.FindNeighboringNodes(NumberNode{0}))
+	assert.Equal(t, []graph.Node[int]{NumberNode{1}}, base.FindNeighboringNodes(NumberNode{0}))
+
+	edges := g.GetEdges()
+	assert.Len(t, edges, 2000)
+	assert.Equal(t, graph.Node[int](NumberNode{1999}), edges[1999].U())
+	assert.Equal(t, []graph.Node[int]{NumberNode{0}}, g.GetRootNodes())
+}
+
+func TestRemoveEdges(t *testing.T) {
+	g := graph.CreateUndirected[int]()
+	g = g.AddEdge(NumberNode{1}, NumberNode{2}, 1)
+	g = g.AddEdge(NumberNode{2}, NumberNode{1}, 2)
+	g = g.AddEdge(NumberNode{2}, NumberNode{3}, 3)
+
+	// Undirected edges are matched in either orientation.
+	single := g.RemoveEdge(NumberNode{1}, NumberNode{2})
+	assert.Equal(t, 2, single.GetNumberOfEdges())
+	assert.Equal(t, 2, single.FindOutDegree(NumberNode{2}))
+	all := g.RemoveEdgesBetween(NumberNode{2}, NumberNode{1})
+	assert.Equal(t, 1, all.GetNumberOfEdges())
+	assert.Empty(t, all.FindNeighboringNodes(NumberNode{1}))
+	// Nodes stay around, only the edges go away.
+	assert.Equal(t, 3, all.GetNumberOfNodes())
+	assert.Equal(t, 3, g.GetNumberOfEdges())
+
+	directed := g.ToDirected()
+	assert.Equal(t, 3, directed.RemoveEdge(NumberNode{3}, NumberNode{2}).GetNumberOfEdges())
+	assert.Equal(t, 2, directed.RemoveEdgesBetween(NumberNode{1}, NumberNode{2}).GetNumberOfEdges())
+	assert.True(t, directed.RemoveEdge(NumberNode{1}, NumberNode{2}).IsDirectedGraph())
+}
+
+func TestRemoveNode(t *testing.T) {
+	g := abc().AddEdge(StringNode{"B"}, StringNode{"B"}, 0)
+	removed := g.RemoveNode(StringNode{"B"})
+	assert.Equal(t, 2, removed.GetNumberOfNodes())
+	assert.Equal(t, 1, removed.GetNumberOfEdges())
+	assert.False(t, removed.ContainsNode(StringNode{"B"}))
+	assert.Equal(t, []graph.Node[string]{StringNode{"A"}, StringNode{"C"}}, removed.GetNodes())
+	assert.True(t, removed.IsDAG())
+	assert.Equal(t, 4, g.GetNumberOfEdges())
+
+	// Undirected edges are dropped no matter which end they were added from.
+	undirected := graph.CreateUndirected[int]().
+		AddEdge(NumberNode{1}, NumberNode{2}, 0).
+		AddEdge(NumberNode{3}, NumberNode{1}, 0).
+		AddEdge(NumberNode{2}, NumberNode{3}, 0)
+	withoutOne := undirected.RemoveNode(NumberNode{1})
+	assert.Equal(t, 1, withoutOne.GetNumberOfEdges())
+	assert.Equal(t, []graph.Node[int]{NumberNode{3}}, withoutOne.FindNeighboringNodes(NumberNode{2}))
+
+	// Nodes can be added back after being removed.
+	readded := withoutOne.AddEdge(NumberNode{1}, NumberNode{2}, 0)
+	assert.Equal(t, []graph.Node[int]{NumberNode{2}, NumberNode{3}, NumberNode{1}}, readded.GetNodes())
+	assert.Equal(t, withoutOne, withoutOne.RemoveNode(NumberNode{42}))
+}
+
+func TestRemoveNodeHashCollisions(t *testing.T) {
+	// The colliding nodes share a bucket, 39 shares the low bits of their hash and 1, 33 and 1057 share ever more low
+	// bits with each other, so removing them has to take buckets apart and fold subtrees of the index back together.
+	ring := []graph.Node[int]{
+		CollidingNode{100}, NumberNode{1}, NumberNode{33}, CollidingNode{101},
+		NumberNode{1057}, NumberNode{39}, CollidingNode{102},
+	}
+	g := graph.CreateUndirected[int]().AddEdge(CollidingNode{101}, CollidingNode{101}, 0)
+	for idx, node := range ring {
+		g = g.AddEdge(node, ring[(idx+1)%len(ring)], float64(idx))
+	}
+	// The self loop only counts once next to the two ring edges.
+	assert.Equal(t, 3, g.FindOutDegree(CollidingNode{101}))
+	for removed, node := range ring {
+		g = g.RemoveNode(node)
+		assert.False(t, g.ContainsNode(node))
+		assert.Equal(t, len(ring)-removed-1, g.GetNumberOfNodes())
+		// A graph rebuilt from what is left must agree with the one nodes were removed from.
+		rebuilt := graph.CreateUndirected[int]()
+		for _, edge := range g.GetEdges() {
+			rebuilt = rebuilt.AddEdge(edge.U(), edge.V(), edge.Weight())
+		}
+		for _, other := range ring[removed+1:] {
+			assert.True(t, g.ContainsNode(other))
+			assert.Equal(t, rebuilt.FindNeighboringNodes(other), g.FindNeighboringNodes(other))
+			assert.Equal(t, rebuilt.FindOutDegree(other), g.FindOutDegree(other))
+		}
+	}
+	assert.Equal(t, 0, g.GetNumberOfEdges())
+	// The emptied graph can be filled again.
+	g = g.AddEdge(CollidingNode{100}, CollidingNode{101}, 0)
+	assert.Equal(t, []graph.Node[int]{CollidingNode{100}}, g.FindNeighboringNodes(CollidingNode{101}))
+}
+
+func TestContractEdge(t *testing.T) {
+	g := graph.CreateDirected[int]().
+		AddEdge(NumberNode{1}, NumberNode{2}, 1).
+		AddEdge(NumberNode{2}, NumberNode{3}, 2).
+		AddEdge(NumberNode{4}, NumberNode{2}, 3).
+		AddEdge(NumberNode{2}, NumberNode{1}, 4)
+
+	contracted := g.ContractEdge(NumberNode{1}, NumberNode{2})
+	assert.False(t, contracted.ContainsNode(NumberNode{2}))
+	assert.Equal(t, 2, contracted.GetNumberOfEdges())
+	edges := contracted.GetEdges()
+	assert.Equal(t, graph.Node[int](NumberNode{1}), edges[0].U())
+	assert.Equal(t, graph.Node[int](NumberNode{3}), edges[0].V())
+	assert.Equal(t, graph.Node[int](NumberNode{4}), edges[1].U())
+	assert.Equal(t, graph.Node[int](NumberNode{1}), edges[1].V())
+
+	// Contracting nodes without an edge between them changes nothing.
+	assert.Equal(t, g, g.ContractEdge(NumberNode{3}, NumberNode{4}))
+}
+
+func TestFilterGraph(t *testing.T) {
+	g := abc().AddNode(StringNode{"D"})
+	filtered := graph.FilterGraph(g, func(e graph.Edge[string]) bool { return !e.V().Equal(StringNode{"A"}) })
+	assert.True(t, filtered.IsDirectedGraph())
+	assert.Equal(t, 2, filtered.GetNumberOfEdges())
+	// Nodes survive even if none of their edges do.
+	none := graph.FilterGraph(g, func(e graph.Edge[string]) bool { return false })
+	assert.Equal(t, 0, none.GetNumberOfEdges())
+	assert.Equal(t, g.GetNodes(), none.GetNodes())
+	assert.Equal(t, []graph.Node[string]{StringNode{"A"}, StringNode{"B"}, StringNode{"C"}}, filtered.BFS(StringNode{"A"}))
+	assert.Empty(t, filtered.FindEdgesThatLeadTo(StringNode{"A"}))
+
+	undirected := graph.CreateUndirected[int]().AddEdge(NumberNode{1}, NumberNode{2}, 1)
+	assert.False(t, graph.FilterGraph(undirected, func(graph.Edge[int]) bool { return true }).IsDirectedGraph())
+}
+
+func TestFindCycleDirected(t *testing.T) {
+	assert.Equal(t, []graph.Node[string]{StringNode{"A"}, StringNode{"B"}, StringNode{"C"}}, abc().FindCycle())
+	assert.Nil(t, abcNoEdges().FindCycle())
+
+	// A node that is reachable twice is not a cycle on its own.
+	diamond := graph.CreateDirected[int]().
+		AddEdge(NumberNode{1}, NumberNode{2}, 0).
+		AddEdge(NumberNode{1}, NumberNode{3}, 0).
+		AddEdge(NumberNode{2}, NumberNode{4}, 0).
+		AddEdge(NumberNode{3}, NumberNode{4}, 0)
+	assert.False(t, diamond.ContainsCycle())
+	assert.True(t, diamond.IsDAG())
+
+	withBackEdge := diamond.AddEdge(NumberNode{4}, NumberNode{3}, 0)
+	assert.Equal(t, []graph.Node[int]{NumberNode{4}, NumberNode{3}}, withBackEdge.FindCycle())
+	assert.Equal(t, []graph.Node[int]{NumberNode{2}}, diamond.AddEdge(NumberNode{2}, NumberNode{2}, 0).FindCycle())
+}
+
+func TestFindCycleUndirected(t *testing.T) {
+	path := graph.CreateUndirected[int]().
+		AddEdge(NumberNode{1}, NumberNode{2}, 0).
+		AddEdge(NumberNode{3}, NumberNode{2}, 0).
+		AddNode(NumberNode{4})
+	// A single undirected edge is not a cycle.
+	assert.False(t, path.ContainsCycle())
+	assert.Nil(t, path.FindCycle())
+
+	triangle := path.AddEdge(NumberNode{1}, NumberNode{3}, 0)
+	assert.True(t, triangle.ContainsCycle())
+	assert.Equal(t, []graph.Node[int]{NumberNode{1}, NumberNode{2}, NumberNode{3}}, triangle.FindCycle())
+
+	// Parallel edges and self loops are cycles of their own.
+	parallel := path.AddEdge(NumberNode{2}, NumberNode{1}, 0)
+	assert.Equal(t, []graph.Node[int]{NumberNode{1}, NumberNode{2}}, parallel.FindCycle())
+	selfLoop := path.AddEdge(NumberNode{4}, NumberNode{4}, 0)
+	assert.Equal(t, []graph.Node[int]{NumberNode{4}}, selfLoop.FindCycle())
+}
@@ -0,0 +1,119 @@
+package graph
+
+import "slices"
+
+// A snapshot of a graph with its nodes numbered 0..n-1 in insertion order. Algorithms that do a lot of bookkeeping per
+// node work on these numbers and plain slices instead of hashing nodes over and over.
+type indexedGraph[T any] struct {
+	nodes []Node[T]
+	ids   *nodeMap[T, int]
+	out   [][]indexedEdge // edges leading from each node, as reported by FindEdgesThatLeadFrom
+	in    [][]indexedEdge // edges leading to each node, as reported by FindEdgesThatLeadTo
+}
+
+type indexedEdge struct {
+	to     int // the other end of the edge
+	weight float64
+}
+
+func (g Graph[T]) toIndexed() indexedGraph[T] {
+	nodes := g.GetNodes()
+	ids := newNodeMap[T, int]()
+	for idx, node := range nodes {
+		ids.put(node, idx)
+	}
+	out := make([][]indexedEdge, len(nodes))
+	in := make([][]indexedEdge, len(nodes))
+	for idx, node := range nodes {
+		for _, edge := range g.FindEdgesThatLeadFrom(node) {
+			to, _ := ids.get(edge.v)
+			out[idx] = append(out[idx], indexedEdge{to, edge.weight})
+		}
+		for _, edge := range g.FindEdgesThatLeadTo(node) {
+			from, _ := ids.get(edge.u)
+			in[idx] = append(in[idx], indexedEdge{from, edge.weight})
+		}
+	}
+	return indexedGraph[T]{nodes: nodes, ids: ids, out: out, in: in}
+}
+
+func (ig indexedGraph[T]) id(node Node[T]) (int, bool) {
+	return ig.ids.get(node)
+}
+
+func (ig indexedGraph[T]) toNodes(ids []int) []Node[T] {
+	nodes := make([]Node[T], 0, len(ids))
+	for _, id := range ids {
+		nodes = append(nodes, ig.nodes[id])
+	}
+	return nodes
+}
+
+// Computes the strongly connected components of the subgraph induced by the given members, using an iterative version
+// of Tarjan's algorithm. Edges are only followed to nodes marked in alive, which must include every member.
+// Components come out in reverse topological order, i.e. a component is only emitted once every component it leads to
+// has been. Nodes within a component are sorted ascending. Bookkeeping is proportional to the subgraph, not the whole
+// graph, so repeatedly splitting a large graph stays cheap.
+func (ig indexedGraph[T]) stronglyConnected(members []int, alive []bool) [][]int {
+	type frame struct {
+		node int
+		next int
+	}
+	index := map[int]int{}
+	lowLink := map[int]int{}
+	onStack := map[int]bool{}
+	components := [][]int{}
+	stack := []int{}
+	visit := func(node int) {
+		index[node], lowLink[node] = len(index), len(index)
+		stack = append(stack, node)
+		onStack[node] = true
+	}
+	for _, root := range members {
+		if _, ok := index[root]; ok {
+			continue
+		}
+		visit(root)
+		callStack := []frame{{node: root}}
+		for len(callStack) > 0 {
+			top := &callStack[len(callStack)-1]
+			if top.next < len(ig.out[top.node]) {
+				to := ig.out[top.node][top.next].to
+				top.next++
+				if !alive[to] {
+					continue
+				}
+				if _, ok := index[to]; !ok {
+					visit(to)
+					callStack = append(callStack, frame{node: to})
+				} else if onStack[to] {
+					lowLink[top.node] = min(lowLink[top.node], index[to])
+				}
+				continue
+			}
+			node := top.node
+			callStack = callStack[:len(callStack)-1]
+			if len(callStack) > 0 {
+				parent := callStack[len(callStack)-1].node
+				lowLink[parent] = min(lowLink[parent], lowLink[node])
+			}
+			if lowLink[node] != index[node] {
+				continue
+			}
+			// The node is the root of a component, everything above it on the stack belongs to it.
+			component := []int{}
+			for {
+				member := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[member] = false
+				component = append(component, member)
+				if member == node {
+					break
+				}
+			}
+			slices.Sort(component)
+			components = append(components, component)
+		}
+	}
+	return components
+}
@@ -0,0 +1,85 @@
+package graph
+
+import "iter"
+
+// Iterates over every node of this graph in the order they were added, like GetNodes without collecting them first.
+func (g Graph[T]) Nodes() iter.Seq[Node[T]] {
+	return func(yield func(Node[T]) bool) {
+		for _, node := range g.nodes.all() {
+			if !yield(node) {
+				return
+			}
+		}
+	}
+}
+
+// Iterates over every edge of this graph in the order they were added, like GetEdges without collecting them first.
+func (g Graph[T]) Edges() iter.Seq[Edge[T]] {
+	return func(yield func(Edge[T]) bool) {
+		for _, edge := range g.edges.all() {
+			if !yield(edge) {
+				return
+			}
+		}
+	}
+}
+
+// Iterates over the edges that lead from the given node, in the same order as FindEdgesThatLeadFrom. Yields nothing if
+// the node is not part of this graph.
+func (g Graph[T]) OutEdges(source Node[T]) iter.Seq[Edge[T]] {
+	return func(yield func(Edge[T]) bool) {
+		adj, ok := g.lookup(source)
+		if !ok {
+			return
+		}
+		if g.directed {
+			g.incidentSeq(adj.out, seqMap[struct{}]{})(yield)
+			return
+		}
+		g.incidentSeq(adj.out, adj.in)(yield)
+	}
+}
+
+// Iterates over the edges that lead to the given node, in the same order as FindEdgesThatLeadTo. Yields nothing if the
+// node is not part of this graph.
+func (g Graph[T]) InEdges(target Node[T]) iter.Seq[Edge[T]] {
+	return func(yield func(Edge[T]) bool) {
+		adj, ok := g.lookup(target)
+		if !ok {
+			return
+		}
+		if g.directed {
+			g.incidentSeq(adj.in, seqMap[struct{}]{})(yield)
+			return
+		}
+		g.incidentSeq(adj.in, adj.out)(yield)
+	}
+}
+
+// Iterates over the nodes in the same order as DFS, exploring the graph only as far as the caller keeps asking. Like
+// DFS, a source that is not part of this graph is the only node visited.
+func (g Graph[T]) DFSSeq(source Node[T]) iter.Seq[Node[T]] {
+	return func(yield func(Node[T]) bool) {
+		err := g.Walk(source, Visitor[T]{
+			OnDiscover: func(node Node[T], depth int) WalkAction {
+				if !yield(node) {
+					return WalkStop
+				}
+				return WalkContinue
+			},
+		})
+		if err != nil {
+			yield(source)
+		}
+	}
+}
+
+// Iterates over the nodes in the same order as BFS, exploring the graph only as far as the caller keeps asking. Like
+// BFS, a source that is not part of this graph is the only node visited.
+func (g Graph[T]) BFSSeq(source Node[T]) iter.Seq[Node[T]] {
+	return func(yield func(Node[T]) bool) {
+		g.bfs(source, func(node Node[T], parent int) bool {
+			return yield(node)
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package graph_test
+
+import (
+	"graph"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIteratorsMatchSlices(t *testing.T) {
+	random := rand.New(rand.NewSource(9))
+	for round := range 20 {
+		g := graph.CreateDirected[int]()
+		if round%2 == 1 {
+			g = graph.CreateUndirected[int]()
+		}
+		g = g.AddNode(NumberNode{99})
+		for range 25 {
+			// Few nodes make parallel edges and self loops likely.
+			g = g.AddEdge(NumberNode{random.Intn(8)}, NumberNode{random.Intn(8)}, float64(random.Intn(5)))
+		}
+		assert.Equal(t, g.GetNodes(), slices.Collect(g.Nodes()))
+		assert.Equal(t, g.GetEdges(), slices.Collect(g.Edges()))
+		for _, node := range g.GetNodes() {
+			// Collecting an empty sequence gives nil rather than an empty slice.
+			out := append([]graph.Edge[int]{}, slices.Collect(g.OutEdges(node))...)
+			in := append([]graph.Edge[int]{}, slices.Collect(g.InEdges(node))...)
+			assert.Equal(t, g.FindEdgesThatLeadFrom(node), out)
+			assert.Equal(t, g.FindEdgesThatLeadTo(node), in)
+			assert.Equal(t, g.DFS(node), slices.Collect(g.DFSSeq(node)))
+			assert.Equal(t, g.BFS(node), slices.Collect(g.BFSSeq(node)))
+		}
+	}
+}
+
+func TestIteratorsStopEarly(t *testing.T) {
+	g := forestGraph()
+	first := []graph.Node[int]{}
+	for node := range g.BFSSeq(NumberNode{1}) {
+		first = append(first, node)
+		if len(first) == 2 {
+			break
+		}
+	}
+	assert.Equal(t, []graph.Node[int]{NumberNode{1}, NumberNode{2}}, first)
+
+	for node := range g.DFSSeq(NumberNode{1}) {
+		if node.Val() == 3 {
+			break
+		}
+		assert.NotEqual(t, 4, node.Val())
+	}
+	for edge := range g.OutEdges(NumberNode{1}) {
+		assert.Equal(t, graph.CreateEdge[int](NumberNode{1}, NumberNode{2}, 0), edge)
+		break
+	}
+	for range g.Edges() {
+		break
+	}
+	for range g.Nodes() {
+		break
+	}
+
+	assert.Empty(t, slices.Collect(g.OutEdges(NumberNode{42})))
+	assert.Empty(t, slices.Collect(g.InEdges(NumberNode{42})))
+}
+
+func TestTraversalSeqMissingSource(t *testing.T) {
+	g := forestGraph()
+	// A source outside of the graph is visited on its own, by the slices and the iterators alike.
+	missing := NumberNode{42}
+	assert.Equal(t, []graph.Node[int]{missing}, g.BFS(missing))
+	assert.Equal(t, g.BFS(missing), slices.Collect(g.BFSSeq(missing)))
+	assert.Equal(t, g.DFS(missing), slices.Collect(g.DFSSeq(missing)))
+	for range g.BFSSeq(missing) {
+		break
+	}
+	for range g.DFSSeq(missing) {
+		break
+	}
+}
@@ -0,0 +1,103 @@
+package graph
+
+import (
+	"cmp"
+	"slices"
+)
+
+// A path through a graph together with its total weight.
+type Path[T any] struct {
+	Nodes  []Node[T]
+	Weight float64
+}
+
+// Finds up to k shortest loopless paths from the source to the target with Yen's algorithm, cheapest first. Paths are
+// told apart by their nodes, so parallel edges never yield the same path twice and a path is always weighted by its
+// lightest edges. Returns fewer than k paths if there are not that many, and none at all if the target cannot be
+// reached. Fails with a NodeNotFoundError if either node is not part of this graph and with a NegativeWeightError if
+// any edge weight is negative.
+func (g Graph[T]) KShortestPaths(source Node[T], target Node[T], k int) ([]Path[T], error) {
+	ig := g.toIndexed()
+	ids, err := g.prepareDijkstras(ig, source, target)
+	if err != nil {
+		return nil, err
+	}
+	from, to := ids[0], ids[1]
+	paths := []Path[T]{}
+	if k <= 0 {
+		return paths, nil
+	}
+	first := ig.dijkstras(from, to, nil)
+	if !first.settled[to] {
+		return paths, nil
+	}
+
+	type candidate struct {
+		nodes  []int
+		weight float64
+	}
+	found := []candidate{{first.pathTo(to), first.dist[to]}}
+	pending := []candidate{}
+	known := func(nodes []int) bool {
+		isSame := func(c candidate) bool { return slices.Equal(c.nodes, nodes) }
+		return slices.ContainsFunc(found, isSame) || slices.ContainsFunc(pending, isSame)
+	}
+	for len(found) < k {
+		previous := found[len(found)-1].nodes
+		// Every node but the target of the previous path is tried as the spur where a new path branches off.
+		for spurIdx := 0; spurIdx < len(previous)-1; spurIdx++ {
+			spur := previous[spurIdx]
+			rootPath := previous[:spurIdx+1]
+			bannedNodes := map[int]bool{}
+			for _, node := range rootPath[:spurIdx] {
+				bannedNodes[node] = true
+			}
+			// Paths sharing this root may not leave the spur the same way again.
+			bannedNext := map[int]bool{}
+			for _, path := range found {
+				if len(path.nodes) > spurIdx+1 && slices.Equal(path.nodes[:spurIdx+1], rootPath) {
+					bannedNext[path.nodes[spurIdx+1]] = true
+				}
+			}
+			spurTree := ig.dijkstras(spur, to, func(curr int, edge indexedEdge) bool {
+				return !bannedNodes[edge.to] && !(curr == spur && bannedNext[edge.to])
+			})
+			if !spurTree.settled[to] {
+				continue
+			}
+			nodes := slices.Concat(rootPath[:spurIdx], spurTree.pathTo(to))
+			if !known(nodes) {
+				pending = append(pending, candidate{nodes, ig.pathWeight(rootPath) + spurTree.dist[to]})
+			}
+		}
+		if len(pending) == 0 {
+			break
+		}
+		// Take the cheapest candidate, preferring fewer hops on ties.
+		best := slices.MinFunc(pending, func(a, b candidate) int {
+			return cmp.Or(cmp.Compare(a.weight, b.weight), cmp.Compare(len(a.nodes), len(b.nodes)))
+		})
+		pending = slices.DeleteFunc(pending, func(c candidate) bool { return slices.Equal(c.nodes, best.nodes) })
+		found = append(found, best)
+	}
+
+	for _, path := range found {
+		paths = append(paths, Path[T]{Nodes: ig.toNodes(path.nodes), Weight: path.weight})
+	}
+	return paths, nil
+}
+
+// Sums the weights along the path, taking the lightest edge wherever there are parallel ones.
+func (ig indexedGraph[T]) pathWeight(path []int) float64 {
+	total := 0.0
+	for idx := 1; idx < len(path); idx++ {
+		lightest := -1.0
+		for _, edge := range ig.out[path[idx-1]] {
+			if edge.to == path[idx] && (lightest < 0 || edge.weight < lightest) {
+				lightest = edge.weight
+			}
+		}
+		total += lightest
+	}
+	return total
+}
@@ -0,0 +1,72 @@
+package graph_test
+
+import (
+	"graph"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func yen() graph.Graph[string] {
+	return graph.CreateDirected[string]().
+		AddEdge(StringNode{"C"}, StringNode{"D"}, 3).
+		AddEdge(StringNode{"C"}, StringNode{"E"}, 2).
+		AddEdge(StringNode{"D"}, StringNode{"F"}, 4).
+		AddEdge(StringNode{"E"}, StringNode{"D"}, 1).
+		AddEdge(StringNode{"E"}, StringNode{"F"}, 2).
+		AddEdge(StringNode{"E"}, StringNode{"G"}, 3).
+		AddEdge(StringNode{"F"}, StringNode{"G"}, 2).
+		AddEdge(StringNode{"F"}, StringNode{"H"}, 1).
+		AddEdge(StringNode{"G"}, StringNode{"H"}, 2)
+}
+
+func pathValues(paths []graph.Path[string]) ([]string, []float64) {
+	routes := []string{}
+	weights := []float64{}
+	for _, path := range paths {
+		route := ""
+		for _, node := range path.Nodes {
+			route += node.Val()
+		}
+		routes = append(routes, route)
+		weights = append(weights, path.Weight)
+	}
+	return routes, weights
+}
+
+func TestKShortestPaths(t *testing.T) {
+	paths, err := yen().KShortestPaths(StringNode{"C"}, StringNode{"H"}, 3)
+	assert.NoError(t, err)
+	routes, weights := pathValues(paths)
+	assert.Equal(t, []string{"CEFH", "CEGH", "CDFH"}, routes)
+	assert.Equal(t, []float64{5, 7, 8}, weights)
+
+	// Asking for more paths than exist returns all of them.
+	paths, err = yen().KShortestPaths(StringNode{"C"}, StringNode{"H"}, 100)
+	assert.NoError(t, err)
+	routes, _ = pathValues(paths)
+	assert.Len(t, routes, 7)
+	assert.ElementsMatch(t, []string{"CEFH", "CEGH", "CDFH", "CEDFH", "CEFGH", "CDFGH", "CEDFGH"}, routes)
+
+	paths, err = yen().KShortestPaths(StringNode{"H"}, StringNode{"C"}, 3)
+	assert.NoError(t, err)
+	assert.Empty(t, paths)
+	_, err = yen().KShortestPaths(StringNode{"C"}, StringNode{"Z"}, 3)
+	assert.ErrorIs(t, err, graph.ErrNodeNotFound)
+}
+
+func TestKShortestPathsUndirectedAndParallel(t *testing.T) {
+	g := graph.CreateUndirected[int]().
+		AddEdge(NumberNode{1}, NumberNode{2}, 1).
+		AddEdge(NumberNode{2}, NumberNode{1}, 5).
+		AddEdge(NumberNode{2}, NumberNode{3}, 1).
+		AddEdge(NumberNode{3}, NumberNode{1}, 4)
+	paths, err := g.KShortestPaths(NumberNode{1}, NumberNode{3}, 5)
+	assert.NoError(t, err)
+	// The parallel edge between 1 and 2 does not produce a second, heavier copy of the same route.
+	assert.Len(t, paths, 2)
+	assert.Equal(t, []graph.Node[int]{NumberNode{1}, NumberNode{2}, NumberNode{3}}, paths[0].Nodes)
+	assert.Equal(t, 2.0, paths[0].Weight)
+	assert.Equal(t, []graph.Node[int]{NumberNode{1}, NumberNode{3}}, paths[1].Nodes)
+	assert.Equal(t, 4.0, paths[1].Weight)
+}
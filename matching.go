@@ -0,0 +1,271 @@
+package graph
+
+import "math"
+
+// Splits the nodes into a left and a right side so that every edge runs between both sides, ignoring edge directions.
+// The earliest added node of every connected component goes on the left, and both sides keep the order the nodes were
+// added in. Fails with an OddCycleError if no such split exists.
+func (g Graph[T]) IsBipartite() ([]Node[T], []Node[T], error) {
+	ig := g.toIndexed()
+	side, cycle := ig.bipartition()
+	if cycle != nil {
+		return nil, nil, &OddCycleError[T]{Cycle: ig.toNodes(cycle)}
+	}
+	left, right := []Node[T]{}, []Node[T]{}
+	for node, s := range side {
+		if s == 0 {
+			left = append(left, ig.nodes[node])
+		} else {
+			right = append(right, ig.nodes[node])
+		}
+	}
+	return left, right, nil
+}
+
+// Colors every node with 0 or 1 with a BFS per connected component, so that neighbors get different colors. Returns an
+// odd cycle instead if two neighbors are forced to share a color.
+func (ig indexedGraph[T]) bipartition() ([]int, []int) {
+	side := make([]int, len(ig.nodes))
+	parent := make([]int, len(ig.nodes))
+	for node := range side {
+		side[node] = -1
+	}
+	for root := range side {
+		if side[root] != -1 {
+			continue
+		}
+		side[root], parent[root] = 0, -1
+		queue := []int{root}
+		for len(queue) > 0 {
+			curr := queue[0]
+			queue = queue[1:]
+			for _, edges := range [][]indexedEdge{ig.out[curr], ig.in[curr]} {
+				for _, edge := range edges {
+					if side[edge.to] == -1 {
+						side[edge.to], parent[edge.to] = 1-side[curr], curr
+						queue = append(queue, edge.to)
+					} else if side[edge.to] == side[curr] {
+						return nil, oddCycle(parent, curr, edge.to)
+					}
+				}
+			}
+		}
+	}
+	return side, nil
+}
+
+// Closes the cycle formed by the edge between two nodes of the same BFS level and their paths up the BFS tree. The
+// cycle starts where both paths meet, runs down to the first node and back up from the second one.
+func oddCycle(parent []int, first int, second int) []int {
+	down, up := []int{}, []int{}
+	for first != second {
+		down = append(down, first)
+		up = append(up, second)
+		first, second = parent[first], parent[second]
+	}
+	cycle := []int{first}
+	for i := len(down) - 1; i >= 0; i-- {
+		cycle = append(cycle, down[i])
+	}
+	return append(cycle, up...)
+}
+
+// A bipartite graph with its edges oriented from the left to the right side.
+type bipartiteGraph[T any] struct {
+	ig    indexedGraph[T]
+	left  []int
+	right []int
+	adj   map[int][]int      // the right neighbors of every left node, in the order their first edge was added
+	edges map[[2]int]Edge[T] // the lightest edge between a left and a right node, the earliest added one on ties
+}
+
+func (g Graph[T]) toBipartite() (bipartiteGraph[T], error) {
+	ig := g.toIndexed()
+	side, cycle := ig.bipartition()
+	if cycle != nil {
+		return bipartiteGraph[T]{}, &OddCycleError[T]{Cycle: ig.toNodes(cycle)}
+	}
+	b := bipartiteGraph[T]{ig: ig, adj: map[int][]int{}, edges: map[[2]int]Edge[T]{}}
+	for node, s := range side {
+		if s == 0 {
+			b.left = append(b.left, node)
+		} else {
+			b.right = append(b.right, node)
+		}
+	}
+	for _, edge := range g.GetEdges() {
+		l, _ := ig.id(edge.u)
+		r, _ := ig.id(edge.v)
+		if side[l] == 1 {
+			l, r = r, l
+		}
+		key := [2]int{l, r}
+		if lightest, ok := b.edges[key]; !ok {
+			b.adj[l] = append(b.adj[l], r)
+			b.edges[key] = edge
+		} else if edge.weight < lightest.weight {
+			b.edges[key] = edge
+		}
+	}
+	return b, nil
+}
+
+// Finds a maximum cardinality matching with the Hopcroft-Karp algorithm, i.e. as many edges as possible without two of
+// them sharing a node. Edge directions are ignored. The matched edges are ordered by their left node, see
+// IsBipartite for the sides. Fails with an OddCycleError if this graph is not bipartite.
+func (g Graph[T]) HopcroftKarp() ([]Edge[T], error) {
+	b, err := g.toBipartite()
+	if err != nil {
+		return nil, err
+	}
+	mate := make([]int, len(b.ig.nodes))
+	for node := range mate {
+		mate[node] = -1
+	}
+	dist := make([]int, len(b.ig.nodes))
+	// Layers the left nodes by the length of the shortest alternating path from a free left node. Reports whether a
+	// free right node can be reached at all.
+	layer := func() bool {
+		queue := []int{}
+		for _, l := range b.left {
+			if mate[l] == -1 {
+				dist[l] = 0
+				queue = append(queue, l)
+			} else {
+				dist[l] = math.MaxInt
+			}
+		}
+		found := false
+		for len(queue) > 0 {
+			l := queue[0]
+			queue = queue[1:]
+			for _, r := range b.adj[l] {
+				if mate[r] == -1 {
+					found = true
+				} else if dist[mate[r]] == math.MaxInt {
+					dist[mate[r]] = dist[l] + 1
+					queue = append(queue, mate[r])
+				}
+			}
+		}
+		return found
+	}
+	var augment func(l int) bool
+	augment = func(l int) bool {
+		for _, r := range b.adj[l] {
+			if mate[r] == -1 || dist[mate[r]] == dist[l]+1 && augment(mate[r]) {
+				mate[l], mate[r] = r, l
+				return true
+			}
+		}
+		// A dead end, no other path in this phase needs to look at it again.
+		dist[l] = math.MaxInt
+		return false
+	}
+	for layer() {
+		for _, l := range b.left {
+			if mate[l] == -1 {
+				augment(l)
+			}
+		}
+	}
+	matching := []Edge[T]{}
+	for _, l := range b.left {
+		if mate[l] != -1 {
+			matching = append(matching, b.edges[[2]int{l, mate[l]}])
+		}
+	}
+	return matching, nil
+}
+
+// Finds a perfect matching of minimum total weight with the Hungarian algorithm, i.e. pairs every node of one side
+// with exactly one node of the other side as cheaply as possible. Edge directions are ignored and negative weights are
+// allowed. Between two nodes joined by parallel edges the lightest one is used. The matched edges are ordered by their
+// left node, see IsBipartite for the sides. Fails with an OddCycleError if this graph is not bipartite and with
+// ErrNoPerfectMatching if the sides differ in size or cannot be paired up completely.
+func (g Graph[T]) MinWeightPerfectMatching() ([]Edge[T], float64, error) {
+	b, err := g.toBipartite()
+	if err != nil {
+		return nil, 0, err
+	}
+	n := len(b.left)
+	if n != len(b.right) {
+		return nil, 0, ErrNoPerfectMatching
+	}
+	// Rows are left nodes and columns right nodes, both counted from 1 so that column 0 can stand in for the row that
+	// is currently being added. Missing edges cost infinitely much.
+	cost := make([][]float64, n+1)
+	for row := range cost {
+		cost[row] = make([]float64, n+1)
+		for col := range cost[row] {
+			cost[row][col] = math.Inf(1)
+		}
+	}
+	for row, l := range b.left {
+		for col, r := range b.right {
+			if edge, ok := b.edges[[2]int{l, r}]; ok {
+				cost[row+1][col+1] = edge.weight
+			}
+		}
+	}
+	rowPotential := make([]float64, n+1)
+	colPotential := make([]float64, n+1)
+	matchedRow := make([]int, n+1) // the row each column is matched to, 0 while it is free
+	way := make([]int, n+1)        // the previous column on the alternating path to each column
+	for row := 1; row <= n; row++ {
+		matchedRow[0] = row
+		col := 0
+		minSlack := make([]float64, n+1)
+		for c := range minSlack {
+			minSlack[c] = math.Inf(1)
+		}
+		used := make([]bool, n+1)
+		// Grows a tree of tight edges from the new row until it reaches a free column, shifting the potentials by the
+		// smallest slack whenever the tree gets stuck.
+		for matchedRow[col] != 0 {
+			used[col] = true
+			curr, delta, next := matchedRow[col], math.Inf(1), 0
+			for c := 1; c <= n; c++ {
+				if used[c] {
+					continue
+				}
+				if slack := cost[curr][c] - rowPotential[curr] - colPotential[c]; slack < minSlack[c] {
+					minSlack[c], way[c] = slack, col
+				}
+				if minSlack[c] < delta {
+					delta, next = minSlack[c], c
+				}
+			}
+			if math.IsInf(delta, 1) {
+				return nil, 0, ErrNoPerfectMatching
+			}
+			for c := 0; c <= n; c++ {
+				if used[c] {
+					rowPotential[matchedRow[c]] += delta
+					colPotential[c] -= delta
+				} else {
+					minSlack[c] -= delta
+				}
+			}
+			col = next
+		}
+		// Flips the alternating path so the new row ends up matched.
+		for col != 0 {
+			prev := way[col]
+			matchedRow[col] = matchedRow[prev]
+			col = prev
+		}
+	}
+	matchedCol := make([]int, n+1)
+	for col := 1; col <= n; col++ {
+		matchedCol[matchedRow[col]] = col
+	}
+	matching := make([]Edge[T], 0, n)
+	total := 0.0
+	for row, l := range b.left {
+		edge := b.edges[[2]int{l, b.right[matchedCol[row+1]-1]}]
+		matching = append(matching, edge)
+		total += edge.weight
+	}
+	return matching, total, nil
+}
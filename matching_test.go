@@ -0,0 +1,181 @@
+package graph_test
+
+import (
+	"errors"
+	"graph"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Reviewers 1 to 3 and changes 10 to 30, weighted by how long each reviewer would take.
+func reviewers() graph.Graph[int] {
+	return graph.CreateUndirected[int]().
+		AddEdge(NumberNode{1}, NumberNode{10}, 4).
+		AddEdge(NumberNode{1}, NumberNode{20}, 1).
+		AddEdge(NumberNode{1}, NumberNode{30}, 3).
+		AddEdge(NumberNode{2}, NumberNode{10}, 2).
+		AddEdge(NumberNode{2}, NumberNode{20}, 0).
+		AddEdge(NumberNode{2}, NumberNode{30}, 5).
+		AddEdge(NumberNode{3}, NumberNode{10}, 3).
+		AddEdge(NumberNode{3}, NumberNode{20}, 2).
+		AddEdge(NumberNode{3}, NumberNode{30}, 2)
+}
+
+func TestIsBipartite(t *testing.T) {
+	left, right, err := reviewers().IsBipartite()
+	assert.NoError(t, err)
+	assert.Equal(t, []graph.Node[int]{NumberNode{1}, NumberNode{2}, NumberNode{3}}, left)
+	assert.Equal(t, []graph.Node[int]{NumberNode{10}, NumberNode{20}, NumberNode{30}}, right)
+
+	// Directions are ignored and every component starts on the left.
+	left, right, err = graph.CreateDirected[int]().
+		AddEdge(NumberNode{2}, NumberNode{1}, 0).
+		AddEdge(NumberNode{3}, NumberNode{1}, 0).
+		AddEdge(NumberNode{4}, NumberNode{5}, 0).
+		IsBipartite()
+	assert.NoError(t, err)
+	assert.Equal(t, []graph.Node[int]{NumberNode{2}, NumberNode{3}, NumberNode{4}}, left)
+	assert.Equal(t, []graph.Node[int]{NumberNode{1}, NumberNode{5}}, right)
+
+	var oddCycle *graph.OddCycleError[int]
+	_, _, err = detour().IsBipartite()
+	assert.ErrorIs(t, err, graph.ErrNotBipartite)
+	assert.True(t, errors.As(err, &oddCycle))
+	assert.Equal(t, []graph.Node[int]{NumberNode{1}, NumberNode{2}, NumberNode{3}}, oddCycle.Cycle)
+
+	_, _, err = graph.CreateUndirected[int]().AddEdge(NumberNode{1}, NumberNode{1}, 0).IsBipartite()
+	assert.True(t, errors.As(err, &oddCycle))
+	assert.Equal(t, []graph.Node[int]{NumberNode{1}}, oddCycle.Cycle)
+}
+
+func TestHopcroftKarp(t *testing.T) {
+	// Only reviewer 1 can take change 30, so greedily giving it change 10 first would leave 30 unreviewed.
+	g := graph.CreateUndirected[int]().
+		AddEdge(NumberNode{1}, NumberNode{10}, 0).
+		AddEdge(NumberNode{1}, NumberNode{30}, 0).
+		AddEdge(NumberNode{2}, NumberNode{10}, 0).
+		AddEdge(NumberNode{2}, NumberNode{20}, 0).
+		AddEdge(NumberNode{3}, NumberNode{20}, 0).
+		AddNode(NumberNode{4})
+	matching, err := g.HopcroftKarp()
+	assert.NoError(t, err)
+	assert.Equal(t, []graph.Edge[int]{
+		graph.CreateEdge[int](NumberNode{1}, NumberNode{30}, 0),
+		graph.CreateEdge[int](NumberNode{2}, NumberNode{10}, 0),
+		graph.CreateEdge[int](NumberNode{3}, NumberNode{20}, 0),
+	}, matching)
+
+	_, err = detour().HopcroftKarp()
+	assert.ErrorIs(t, err, graph.ErrNotBipartite)
+}
+
+func TestHopcroftKarpMatchesMaxFlow(t *testing.T) {
+	random := rand.New(rand.NewSource(6))
+	for range 30 {
+		g := graph.CreateUndirected[int]()
+		network := graph.CreateDirected[int]()
+		for l := 1; l <= 8; l++ {
+			network = network.AddEdge(NumberNode{0}, NumberNode{l}, 1)
+			for r := 11; r <= 18; r++ {
+				if random.Intn(5) == 0 {
+					g = g.AddEdge(NumberNode{l}, NumberNode{r}, 0)
+					network = network.AddEdge(NumberNode{l}, NumberNode{r}, 1)
+				}
+			}
+		}
+		for r := 11; r <= 18; r++ {
+			network = network.AddEdge(NumberNode{r}, NumberNode{99}, 1)
+		}
+		matching, err := g.HopcroftKarp()
+		assert.NoError(t, err)
+		flow, err := network.MaxFlow(NumberNode{0}, NumberNode{99})
+		assert.NoError(t, err)
+		assert.Equal(t, int(flow.Value), len(matching))
+		matched := map[int]bool{}
+		for _, edge := range matching {
+			assert.False(t, matched[edge.U().Val()] || matched[edge.V().Val()])
+			matched[edge.U().Val()], matched[edge.V().Val()] = true, true
+		}
+	}
+}
+
+func TestMinWeightPerfectMatching(t *testing.T) {
+	matching, total, err := reviewers().MinWeightPerfectMatching()
+	assert.NoError(t, err)
+	assert.Equal(t, 5.0, total)
+	assert.Equal(t, []graph.Edge[int]{
+		graph.CreateEdge[int](NumberNode{1}, NumberNode{20}, 1),
+		graph.CreateEdge[int](NumberNode{2}, NumberNode{10}, 2),
+		graph.CreateEdge[int](NumberNode{3}, NumberNode{30}, 2),
+	}, matching)
+
+	// Both sides have the same size, but reviewers 1 and 2 can only take the same change.
+	_, _, err = graph.CreateUndirected[int]().
+		AddEdge(NumberNode{1}, NumberNode{10}, 1).
+		AddEdge(NumberNode{2}, NumberNode{10}, 1).
+		AddEdge(NumberNode{3}, NumberNode{10}, 1).
+		AddEdge(NumberNode{3}, NumberNode{20}, 1).
+		AddEdge(NumberNode{3}, NumberNode{30}, 1).
+		MinWeightPerfectMatching()
+	assert.ErrorIs(t, err, graph.ErrNoPerfectMatching)
+	_, _, err = reviewers().AddEdge(NumberNode{4}, NumberNode{10}, 1).MinWeightPerfectMatching()
+	assert.ErrorIs(t, err, graph.ErrNoPerfectMatching)
+	_, _, err = detour().MinWeightPerfectMatching()
+	assert.ErrorIs(t, err, graph.ErrNotBipartite)
+}
+
+// Tries every assignment of the left nodes 0..n-1 to the right nodes n..2n-1.
+func bruteForceMatching(weights [][]float64) float64 {
+	n := len(weights)
+	best := math.Inf(1)
+	used := make([]bool, n)
+	var assign func(row int, total float64)
+	assign = func(row int, total float64) {
+		if row == n {
+			best = min(best, total)
+			return
+		}
+		for col := range n {
+			if !used[col] && !math.IsInf(weights[row][col], 1) {
+				used[col] = true
+				assign(row+1, total+weights[row][col])
+				used[col] = false
+			}
+		}
+	}
+	assign(0, 0)
+	return best
+}
+
+func TestMinWeightPerfectMatchingMatchesBruteForce(t *testing.T) {
+	random := rand.New(rand.NewSource(7))
+	for range 50 {
+		n := random.Intn(6) + 1
+		weights := make([][]float64, n)
+		g := graph.CreateUndirected[int]()
+		for l := range n {
+			g = g.AddNode(NumberNode{l})
+		}
+		for l := range n {
+			weights[l] = make([]float64, n)
+			for r := range n {
+				weights[l][r] = math.Inf(1)
+				if random.Intn(3) > 0 {
+					weights[l][r] = float64(random.Intn(20) - 5)
+					g = g.AddEdge(NumberNode{l}, NumberNode{n + r}, weights[l][r])
+				}
+			}
+		}
+		expected := bruteForceMatching(weights)
+		_, total, err := g.MinWeightPerfectMatching()
+		if math.IsInf(expected, 1) {
+			assert.ErrorIs(t, err, graph.ErrNoPerfectMatching)
+			continue
+		}
+		assert.NoError(t, err)
+		assert.Equal(t, expected, total)
+	}
+}
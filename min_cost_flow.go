@@ -0,0 +1,106 @@
+package graph
+
+import "math"
+
+// Sends demand units of flow from the source to the sink as cheaply as possible, treating edge weights as capacities
+// and edge costs as the price of each unit running over an edge, see AddEdgeWithCost. Uses successive shortest paths:
+// Bellman-Ford finds initial potentials that make every reduced cost non-negative, so each augmenting path after that
+// is found with Dijkstras. Negative costs are allowed as long as the source cannot reach a cycle of negative total cost
+// with spare capacity. Pass math.Inf(1) as demand for the cheapest maximum flow. Fails with ErrUndirectedGraph on an
+// undirected graph, like MaxFlow for unknown nodes or negative capacities, with a NegativeCycleError for a negative
+// cost cycle and with ErrInsufficientCapacity if the network cannot carry the demand.
+func (g Graph[T]) MinCostFlow(source Node[T], sink Node[T], demand float64) (Flow[T], error) {
+	if !g.directed {
+		return Flow[T]{}, ErrUndirectedGraph
+	}
+	network, err := g.flowNetwork(source, sink)
+	if err != nil {
+		return Flow[T]{}, err
+	}
+	potential, cycle := network.initialPotentials()
+	if cycle != nil {
+		return Flow[T]{}, &NegativeCycleError[T]{Cycle: network.ig.toNodes(cycle)}
+	}
+	if sent := network.successiveShortestPaths(potential, demand); sent < demand && !math.IsInf(demand, 1) {
+		return Flow[T]{}, ErrInsufficientCapacity
+	}
+	return network.result(), nil
+}
+
+// Runs Bellman-Ford over the arcs with spare capacity, weighted by their cost. Nodes the source cannot reach keep a
+// potential of 0, no augmenting path will ever pass through them.
+func (n *flowNetwork[T]) initialPotentials() ([]float64, []int) {
+	residual := indexedGraph[T]{nodes: n.ig.nodes, ids: n.ig.ids, out: make([][]indexedEdge, len(n.arcs))}
+	for from, arcs := range n.arcs {
+		for _, arc := range arcs {
+			if n.residual[arc] > 0 {
+				residual.out[from] = append(residual.out[from], indexedEdge{to: n.to[arc], weight: n.cost[arc]})
+			}
+		}
+	}
+	tree, cycle := residual.bellmanFord(n.source)
+	if cycle != nil {
+		return nil, cycle
+	}
+	potential := make([]float64, len(n.arcs))
+	for node, dist := range tree.dist {
+		if !math.IsInf(dist, 1) {
+			potential[node] = dist
+		}
+	}
+	return potential, nil
+}
+
+// Augments along cheapest paths until the demand is met or the sink cannot be reached anymore. Returns the amount of
+// flow that was sent.
+func (n *flowNetwork[T]) successiveShortestPaths(potential []float64, demand float64) float64 {
+	sent := 0.0
+	for sent < demand {
+		dist := make([]float64, len(n.arcs))
+		via := make([]int, len(n.arcs))
+		for node := range dist {
+			dist[node], via[node] = math.Inf(1), -1
+		}
+		settled := make([]bool, len(n.arcs))
+		dist[n.source] = 0
+		queue := &priorityQueue{}
+		queue.push(n.source, 0)
+		for queue.Len() > 0 {
+			curr := queue.pop().node
+			if settled[curr] {
+				continue
+			}
+			settled[curr] = true
+			for _, arc := range n.arcs[curr] {
+				to := n.to[arc]
+				if n.residual[arc] <= 0 || settled[to] {
+					continue
+				}
+				reduced := n.cost[arc] + potential[curr] - potential[to]
+				if newDist := dist[curr] + reduced; newDist < dist[to] {
+					dist[to], via[to] = newDist, arc
+					queue.push(to, newDist)
+				}
+			}
+		}
+		if !settled[n.sink] {
+			break
+		}
+		// Shifting the potentials by the distances keeps the reduced costs non-negative, including on the reverse
+		// arcs the augmentation is about to open up.
+		for node, done := range settled {
+			if done {
+				potential[node] += dist[node]
+			}
+		}
+		amount := demand - sent
+		for node := n.sink; node != n.source; node = n.to[via[node]^1] {
+			amount = min(amount, n.residual[via[node]])
+		}
+		for node := n.sink; node != n.source; node = n.to[via[node]^1] {
+			n.push(via[node], amount)
+		}
+		sent += amount
+	}
+	return sent
+}
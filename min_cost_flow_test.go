@@ -0,0 +1,97 @@
+package graph_test
+
+import (
+	"graph"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Two warehouses 1 and 2 supplying two stores 3 and 4 through a super source 0 and a super sink 5. Edges carry their
+// capacity as weight and the shipping price per unit as cost.
+func transport() graph.Graph[int] {
+	return graph.CreateDirected[int]().
+		AddEdgeWithCost(NumberNode{0}, NumberNode{1}, 4, 0).
+		AddEdgeWithCost(NumberNode{0}, NumberNode{2}, 3, 0).
+		AddEdgeWithCost(NumberNode{1}, NumberNode{3}, 3, 2).
+		AddEdgeWithCost(NumberNode{1}, NumberNode{4}, 3, 6).
+		AddEdgeWithCost(NumberNode{2}, NumberNode{3}, 2, 3).
+		AddEdgeWithCost(NumberNode{2}, NumberNode{4}, 3, 4).
+		AddEdgeWithCost(NumberNode{3}, NumberNode{5}, 4, 0).
+		AddEdgeWithCost(NumberNode{4}, NumberNode{5}, 3, 0)
+}
+
+func TestMinCostFlow(t *testing.T) {
+	flow, err := transport().MinCostFlow(NumberNode{0}, NumberNode{5}, 7)
+	assert.NoError(t, err)
+	assert.Equal(t, 7.0, flow.Value)
+	assert.Equal(t, 23.0, flow.Cost)
+	flows := []float64{}
+	for _, edge := range flow.Edges {
+		flows = append(flows, edge.Flow)
+	}
+	assert.Equal(t, []float64{4, 3, 3, 1, 1, 2, 4, 3}, flows)
+	assertValidFlow(t, transport(), flow, 0, 5)
+
+	// A smaller demand only uses the cheapest routes.
+	flow, err = transport().MinCostFlow(NumberNode{0}, NumberNode{5}, 4)
+	assert.NoError(t, err)
+	assert.Equal(t, 4.0, flow.Value)
+	assert.Equal(t, 9.0, flow.Cost)
+
+	flow, err = transport().MinCostFlow(NumberNode{0}, NumberNode{5}, math.Inf(1))
+	assert.NoError(t, err)
+	assert.Equal(t, 7.0, flow.Value)
+
+	_, err = transport().MinCostFlow(NumberNode{0}, NumberNode{5}, 8)
+	assert.ErrorIs(t, err, graph.ErrInsufficientCapacity)
+	_, err = spanning().MinCostFlow(NumberNode{1}, NumberNode{4}, 1)
+	assert.ErrorIs(t, err, graph.ErrUndirectedGraph)
+	_, err = transport().MinCostFlow(NumberNode{0}, NumberNode{42}, 1)
+	assert.ErrorIs(t, err, graph.ErrNodeNotFound)
+}
+
+func TestMinCostFlowNegativeCosts(t *testing.T) {
+	g := transport().AddEdgeWithCost(NumberNode{3}, NumberNode{4}, 2, -1)
+	flow, err := g.MinCostFlow(NumberNode{0}, NumberNode{5}, 7)
+	assert.NoError(t, err)
+	assert.Equal(t, 21.0, flow.Cost)
+
+	_, err = g.AddEdgeWithCost(NumberNode{4}, NumberNode{3}, 1, 0).MinCostFlow(NumberNode{0}, NumberNode{5}, 7)
+	assert.ErrorIs(t, err, graph.ErrNegativeCycle)
+}
+
+func TestMinCostFlowMatchesMaxFlow(t *testing.T) {
+	random := rand.New(rand.NewSource(5))
+	for range 20 {
+		g := graph.CreateDirected[int]().AddNode(NumberNode{0}).AddNode(NumberNode{9})
+		for range 30 {
+			u, v := random.Intn(10), random.Intn(10)
+			// Costs that only grow with the node numbers rule out negative cycles.
+			g = g.AddEdgeWithCost(NumberNode{u}, NumberNode{v}, float64(random.Intn(8)), float64(v-u+random.Intn(3)))
+		}
+		maxFlow, err := g.MaxFlow(NumberNode{0}, NumberNode{9})
+		assert.NoError(t, err)
+		flow, err := g.MinCostFlow(NumberNode{0}, NumberNode{9}, math.Inf(1))
+		assert.NoError(t, err)
+		assert.Equal(t, maxFlow.Value, flow.Value)
+		assertValidFlow(t, g, flow, 0, 9)
+	}
+}
+
+func TestEdgeCost(t *testing.T) {
+	edge := graph.CreateEdge[int](NumberNode{1}, NumberNode{2}, 3).WithCost(4)
+	assert.Equal(t, 3.0, edge.Weight())
+	assert.Equal(t, 4.0, edge.Cost())
+
+	g := graph.CreateUndirectedBuilder[int]().AddEdges(edge).AddEdgeWithCost(NumberNode{2}, NumberNode{3}, 1, 5).Freeze()
+	assert.Equal(t, 4.0, g.GetEdges()[0].Cost())
+	// Costs survive reversing, mapping and filtering.
+	assert.Equal(t, 4.0, g.FindEdgesThatLeadTo(NumberNode{1})[0].Cost())
+	mapped := graph.MapGraph(g, func(n graph.Node[int]) graph.Node[int] { return NumberNode{n.Val() * 10} })
+	assert.Equal(t, 5.0, mapped.GetEdges()[1].Cost())
+	filtered := graph.FilterGraph(g, func(e graph.Edge[int]) bool { return e.Cost() > 4 })
+	assert.Equal(t, []graph.Edge[int]{graph.CreateEdge[int](NumberNode{2}, NumberNode{3}, 1).WithCost(5)}, filtered.GetEdges())
+}
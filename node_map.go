@@ -0,0 +1,62 @@
+package graph
+
+// A mutable map keyed by nodes. Nodes are bucketed by their Hash and told apart within a bucket by Equal, so two
+// distinct nodes that happen to share a hash never overwrite each other. Used as scratch state inside algorithms.
+type nodeMap[T any, V any] struct {
+	buckets map[int][]nodeMapEntry[T, V]
+}
+
+type nodeMapEntry[T any, V any] struct {
+	node Node[T]
+	val  V
+}
+
+func newNodeMap[T any, V any]() *nodeMap[T, V] {
+	return &nodeMap[T, V]{buckets: map[int][]nodeMapEntry[T, V]{}}
+}
+
+// Looks up the value stored for the given node.
+func (m *nodeMap[T, V]) get(node Node[T]) (V, bool) {
+	for _, entry := range m.buckets[node.Hash()] {
+		if entry.node.Equal(node) {
+			return entry.val, true
+		}
+	}
+	var zero V
+	return zero, false
+}
+
+func (m *nodeMap[T, V]) has(node Node[T]) bool {
+	_, ok := m.get(node)
+	return ok
+}
+
+// Stores the value for the given node, replacing any previous value.
+func (m *nodeMap[T, V]) put(node Node[T], val V) {
+	hash := node.Hash()
+	bucket := m.buckets[hash]
+	for idx, entry := range bucket {
+		if entry.node.Equal(node) {
+			bucket[idx].val = val
+			return
+		}
+	}
+	m.buckets[hash] = append(bucket, nodeMapEntry[T, V]{node, val})
+}
+
+// A set of nodes, see nodeMap.
+type nodeSet[T any] struct {
+	m *nodeMap[T, struct{}]
+}
+
+func newNodeSet[T any]() nodeSet[T] {
+	return nodeSet[T]{newNodeMap[T, struct{}]()}
+}
+
+func (s nodeSet[T]) add(node Node[T]) {
+	s.m.put(node, struct{}{})
+}
+
+func (s nodeSet[T]) has(node Node[T]) bool {
+	return s.m.has(node)
+}
@@ -0,0 +1,450 @@
+package graph
+
+import (
+	"iter"
+	"math/bits"
+)
+
+// The persistent structures below back Graph. Every update copies only the path from the root to the changed slot and
+// shares everything else with the previous version, so each graph version stays valid after it has been extended.
+
+const (
+	trieBits  = 5
+	trieWidth = 1 << trieBits
+	trieMask  = trieWidth - 1
+)
+
+// Position of the slot for idx within a node that only stores its occupied slots.
+func slotPosition(bitmap uint32, idx uint) int {
+	return bits.OnesCount32(bitmap & (1<<idx - 1))
+}
+
+// A persistent map from non-negative ints to values, stored as a bitmap compressed radix trie. Keys are consumed from
+// their most significant chunk downwards so iteration visits them in ascending order, which lets sequential ids double
+// as an insertion order.
+type seqMap[V any] struct {
+	root  *seqNode[V]
+	shift uint // bits consumed by the levels above the leaves
+	count int
+}
+
+type seqNode[V any] struct {
+	bitmap   uint32
+	children []*seqNode[V] // only set on inner nodes
+	values   []V           // only set on leaves
+}
+
+func (m seqMap[V]) len() int {
+	return m.count
+}
+
+// Number of keys the trie can address without growing another level.
+func (m seqMap[V]) capacity() int {
+	return 1 << (m.shift + trieBits)
+}
+
+func (m seqMap[V]) get(key int) (V, bool) {
+	var zero V
+	if m.root == nil || key < 0 || key >= m.capacity() {
+		return zero, false
+	}
+	node := m.root
+	for shift := m.shift; ; shift -= trieBits {
+		idx := uint(key>>shift) & trieMask
+		if node.bitmap&(1<<idx) == 0 {
+			return zero, false
+		}
+		pos := slotPosition(node.bitmap, idx)
+		if shift == 0 {
+			return node.values[pos], true
+		}
+		node = node.children[pos]
+	}
+}
+
+func (m seqMap[V]) has(key int) bool {
+	_, ok := m.get(key)
+	return ok
+}
+
+// Returns a new map with the key set to the given value.
+func (m seqMap[V]) set(key int, val V) seqMap[V] {
+	if key < 0 {
+		panic("seqMap keys must not be negative")
+	}
+	for key >= m.capacity() {
+		if m.root != nil {
+			m.root = &seqNode[V]{bitmap: 1, children: []*seqNode[V]{m.root}}
+		}
+		m.shift += trieBits
+	}
+	root, added := m.root.set(m.shift, key, val)
+	m.root = root
+	if added {
+		m.count++
+	}
+	return m
+}
+
+func (n *seqNode[V]) set(shift uint, key int, val V) (*seqNode[V], bool) {
+	idx := uint(key>>shift) & trieMask
+	bit := uint32(1) << idx
+	if n == nil {
+		n = &seqNode[V]{}
+	}
+	pos := slotPosition(n.bitmap, idx)
+	exists := n.bitmap&bit != 0
+	copied := &seqNode[V]{bitmap: n.bitmap | bit}
+	if shift == 0 {
+		if exists {
+			copied.values = append([]V(nil), n.values...)
+			copied.values[pos] = val
+		} else {
+			copied.values = make([]V, 0, len(n.values)+1)
+			copied.values = append(copied.values, n.values[:pos]...)
+			copied.values = append(copied.values, val)
+			copied.values = append(copied.values, n.values[pos:]...)
+		}
+		return copied, !exists
+	}
+	var child *seqNode[V]
+	if exists {
+		child = n.children[pos]
+	}
+	child, added := child.set(shift-trieBits, key, val)
+	if exists {
+		copied.children = append([]*seqNode[V](nil), n.children...)
+		copied.children[pos] = child
+	} else {
+		copied.children = make([]*seqNode[V], 0, len(n.children)+1)
+		copied.children = append(copied.children, n.children[:pos]...)
+		copied.children = append(copied.children, child)
+		copied.children = append(copied.children, n.children[pos:]...)
+	}
+	return copied, added
+}
+
+// Returns a new map without the given key. Removing a missing key returns the map unchanged.
+func (m seqMap[V]) delete(key int) seqMap[V] {
+	if !m.has(key) {
+		return m
+	}
+	m.root = m.root.delete(m.shift, key)
+	m.count--
+	return m
+}
+
+// Removes a key that is known to be present. Returns nil once the node has no slots left.
+func (n *seqNode[V]) delete(shift uint, key int) *seqNode[V] {
+	idx := uint(key>>shift) & trieMask
+	pos := slotPosition(n.bitmap, idx)
+	if shift == 0 {
+		if n.bitmap == 1<<idx {
+			return nil
+		}
+		return &seqNode[V]{
+			bitmap: n.bitmap &^ (1 << idx),
+			values: append(append([]V(nil), n.values[:pos]...), n.values[pos+1:]...),
+		}
+	}
+	child := n.children[pos].delete(shift-trieBits, key)
+	if child != nil {
+		children := append([]*seqNode[V](nil), n.children...)
+		children[pos] = child
+		return &seqNode[V]{bitmap: n.bitmap, children: children}
+	}
+	if n.bitmap == 1<<idx {
+		return nil
+	}
+	return &seqNode[V]{
+		bitmap:   n.bitmap &^ (1 << idx),
+		children: append(append([]*seqNode[V](nil), n.children[:pos]...), n.children[pos+1:]...),
+	}
+}
+
+// Builds a map in one go from keys in strictly ascending order and their values, without copying any path along the
+// way like repeated calls to set would.
+func buildSeqMap[V any](keys []int, values []V) seqMap[V] {
+	m := seqMap[V]{count: len(keys)}
+	if len(keys) == 0 {
+		return m
+	}
+	for keys[len(keys)-1] >= m.capacity() {
+		m.shift += trieBits
+	}
+	m.root = buildSeqNode(m.shift, keys, values)
+	return m
+}
+
+// Builds the node for keys that all share the bits above shift.
+func buildSeqNode[V any](shift uint, keys []int, values []V) *seqNode[V] {
+	n := &seqNode[V]{}
+	for start := 0; start < len(keys); {
+		idx := uint(keys[start]>>shift) & trieMask
+		end := start + 1
+		for end < len(keys) && uint(keys[end]>>shift)&trieMask == idx {
+			end++
+		}
+		n.bitmap |= 1 << idx
+		if shift == 0 {
+			n.values = append(n.values, values[start])
+		} else {
+			n.children = append(n.children, buildSeqNode(shift-trieBits, keys[start:end], values[start:end]))
+		}
+		start = end
+	}
+	return n
+}
+
+// Iterates over every key and value in ascending key order.
+func (m seqMap[V]) all() iter.Seq2[int, V] {
+	return func(yield func(int, V) bool) {
+		m.root.each(m.shift, 0, yield)
+	}
+}
+
+func (n *seqNode[V]) each(shift uint, prefix int, yield func(int, V) bool) bool {
+	if n == nil {
+		return true
+	}
+	pos := 0
+	for idx := range trieWidth {
+		if n.bitmap&(1<<idx) == 0 {
+			continue
+		}
+		key := prefix | idx<<shift
+		if shift == 0 {
+			if !yield(key, n.values[pos]) {
+				return false
+			}
+		} else if !n.children[pos].each(shift-trieBits, key, yield) {
+			return false
+		}
+		pos++
+	}
+	return true
+}
+
+// Walks the keys of both maps side by side in ascending order, reporting which of the two maps hold each key. Stops as
+// soon as yield returns false. Both tries are walked slot by slot, so nothing is collected up front.
+func (m seqMap[V]) merge(other seqMap[V], yield func(key int, inM bool, inOther bool) bool) {
+	shift := max(m.shift, other.shift)
+	mergeSeqNodes(m.lift(shift), other.lift(shift), shift, 0, yield)
+}
+
+// Returns the root as if the trie had levels down from the given shift. The missing levels above only ever have their
+// first slot occupied.
+func (m seqMap[V]) lift(shift uint) *seqNode[V] {
+	root := m.root
+	for level := m.shift; root != nil && level < shift; level += trieBits {
+		root = &seqNode[V]{bitmap: 1, children: []*seqNode[V]{root}}
+	}
+	return root
+}
+
+func mergeSeqNodes[V any](a, b *seqNode[V], shift uint, prefix int, yield func(int, bool, bool) bool) bool {
+	switch {
+	case a == nil:
+		return b.each(shift, prefix, func(key int, _ V) bool { return yield(key, false, true) })
+	case b == nil:
+		return a.each(shift, prefix, func(key int, _ V) bool { return yield(key, true, false) })
+	}
+	posA, posB := 0, 0
+	for idx := range trieWidth {
+		inA, inB := a.bitmap&(1<<idx) != 0, b.bitmap&(1<<idx) != 0
+		if !inA && !inB {
+			continue
+		}
+		key := prefix | idx<<shift
+		if shift == 0 {
+			if !yield(key, inA, inB) {
+				return false
+			}
+		} else {
+			var childA, childB *seqNode[V]
+			if inA {
+				childA = a.children[posA]
+			}
+			if inB {
+				childB = b.children[posB]
+			}
+			if !mergeSeqNodes(childA, childB, shift-trieBits, key, yield) {
+				return false
+			}
+		}
+		if inA {
+			posA++
+		}
+		if inB {
+			posB++
+		}
+	}
+	return true
+}
+
+// Collects every value in ascending key order.
+func (m seqMap[V]) values() []V {
+	values := make([]V, 0, m.count)
+	for _, val := range m.all() {
+		values = append(values, val)
+	}
+	return values
+}
+
+// A persistent hash array mapped trie from hashes to values. Each level consumes the next few bits of the hash, and a
+// slot either holds a single entry or a subtree for hashes that share those bits. Distinct hashes always separate
+// before the bits run out, so telling apart values that share a hash is left to the caller.
+type hamt[V any] struct {
+	root *hamtNode[V]
+}
+
+type hamtNode[V any] struct {
+	bitmap  uint32
+	entries []hamtEntry[V]
+}
+
+type hamtEntry[V any] struct {
+	child *hamtNode[V] // set when this slot holds a subtree
+	hash  int
+	val   V
+}
+
+func hamtIndex(hash int, shift uint) uint {
+	return uint(uint64(hash)>>shift) & trieMask
+}
+
+func (m hamt[V]) get(hash int) (V, bool) {
+	node := m.root
+	for shift := uint(0); node != nil; shift += trieBits {
+		idx := hamtIndex(hash, shift)
+		if node.bitmap&(1<<idx) == 0 {
+			break
+		}
+		entry := node.entries[slotPosition(node.bitmap, idx)]
+		if entry.child != nil {
+			node = entry.child
+			continue
+		}
+		if entry.hash == hash {
+			return entry.val, true
+		}
+		break
+	}
+	var zero V
+	return zero, false
+}
+
+// Returns a new trie with the hash mapped to the given value.
+func (m hamt[V]) set(hash int, val V) hamt[V] {
+	m.root = m.root.set(0, hamtEntry[V]{hash: hash, val: val})
+	return m
+}
+
+func (n *hamtNode[V]) set(shift uint, leaf hamtEntry[V]) *hamtNode[V] {
+	idx := hamtIndex(leaf.hash, shift)
+	bit := uint32(1) << idx
+	if n == nil {
+		return &hamtNode[V]{bitmap: bit, entries: []hamtEntry[V]{leaf}}
+	}
+	pos := slotPosition(n.bitmap, idx)
+	if n.bitmap&bit == 0 {
+		entries := make([]hamtEntry[V], 0, len(n.entries)+1)
+		entries = append(entries, n.entries[:pos]...)
+		entries = append(entries, leaf)
+		entries = append(entries, n.entries[pos:]...)
+		return &hamtNode[V]{bitmap: n.bitmap | bit, entries: entries}
+	}
+	entries := append([]hamtEntry[V](nil), n.entries...)
+	existing := entries[pos]
+	switch {
+	case existing.child != nil:
+		entries[pos].child = existing.child.set(shift+trieBits, leaf)
+	case existing.hash == leaf.hash:
+		entries[pos] = leaf
+	default:
+		entries[pos] = hamtEntry[V]{child: mergeHamtLeaves(shift+trieBits, existing, leaf)}
+	}
+	return &hamtNode[V]{bitmap: n.bitmap, entries: entries}
+}
+
+// Builds the smallest subtree that holds two entries with different hashes.
+func mergeHamtLeaves[V any](shift uint, a, b hamtEntry[V]) *hamtNode[V] {
+	idxA, idxB := hamtIndex(a.hash, shift), hamtIndex(b.hash, shift)
+	if idxA == idxB {
+		child := mergeHamtLeaves(shift+trieBits, a, b)
+		return &hamtNode[V]{bitmap: 1 << idxA, entries: []hamtEntry[V]{{child: child}}}
+	}
+	if idxB < idxA {
+		a, b = b, a
+	}
+	return &hamtNode[V]{bitmap: 1<<idxA | 1<<idxB, entries: []hamtEntry[V]{a, b}}
+}
+
+// Returns a new trie without the given hash. Removing a missing hash returns the trie unchanged.
+func (m hamt[V]) delete(hash int) hamt[V] {
+	if _, ok := m.get(hash); !ok {
+		return m
+	}
+	m.root = m.root.delete(0, hash)
+	return m
+}
+
+// Removes a hash that is known to be present. Returns nil once the node is empty. A subtree that shrinks to a single
+// entry is folded back into its parent slot so lookups stay short.
+func (n *hamtNode[V]) delete(shift uint, hash int) *hamtNode[V] {
+	idx := hamtIndex(hash, shift)
+	pos := slotPosition(n.bitmap, idx)
+	entry := n.entries[pos]
+	if entry.child != nil {
+		child := entry.child.delete(shift+trieBits, hash)
+		entries := append([]hamtEntry[V](nil), n.entries...)
+		if len(child.entries) == 1 && child.entries[0].child == nil {
+			entries[pos] = child.entries[0]
+		} else {
+			entries[pos] = hamtEntry[V]{child: child}
+		}
+		return &hamtNode[V]{bitmap: n.bitmap, entries: entries}
+	}
+	if len(n.entries) == 1 {
+		return nil
+	}
+	return &hamtNode[V]{
+		bitmap:  n.bitmap &^ (1 << idx),
+		entries: append(append([]hamtEntry[V](nil), n.entries[:pos]...), n.entries[pos+1:]...),
+	}
+}
+
+// Builds a trie in one go from entries with distinct hashes, giving the same shape as inserting them one by one.
+func buildHamt[V any](hashes []int, values []V) hamt[V] {
+	entries := make([]hamtEntry[V], 0, len(hashes))
+	for idx, hash := range hashes {
+		entries = append(entries, hamtEntry[V]{hash: hash, val: values[idx]})
+	}
+	m := hamt[V]{}
+	if len(entries) > 0 {
+		m.root = buildHamtNode(0, entries)
+	}
+	return m
+}
+
+// Builds the node for entries whose hashes share the bits below shift.
+func buildHamtNode[V any](shift uint, entries []hamtEntry[V]) *hamtNode[V] {
+	var slots [trieWidth][]hamtEntry[V]
+	for _, entry := range entries {
+		idx := hamtIndex(entry.hash, shift)
+		slots[idx] = append(slots[idx], entry)
+	}
+	n := &hamtNode[V]{}
+	for idx, slot := range slots {
+		switch len(slot) {
+		case 0:
+			continue
+		case 1:
+			n.entries = append(n.entries, slot[0])
+		default:
+			n.entries = append(n.entries, hamtEntry[V]{child: buildHamtNode(shift+trieBits, slot)})
+		}
+		n.bitmap |= 1 << idx
+	}
+	return n
+}
@@ -0,0 +1,208 @@
+package graph
+
+import (
+	"container/heap"
+	"math"
+	"slices"
+)
+
+// An entry of a priorityQueue. Entries are never updated in place, a node whose priority improves is pushed again and
+// the stale entry is skipped once it surfaces.
+type priorityItem struct {
+	node     int
+	priority float64
+}
+
+// A min heap of node ids ordered by priority, ties are broken by the id so that results are deterministic. Shared by
+// the shortest path searches, which key it by tentative distance or estimated total cost.
+type priorityQueue []priorityItem
+
+func (q priorityQueue) Len() int { return len(q) }
+func (q priorityQueue) Less(i, j int) bool {
+	if q[i].priority != q[j].priority {
+		return q[i].priority < q[j].priority
+	}
+	return q[i].node < q[j].node
+}
+func (q priorityQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *priorityQueue) Push(x any) {
+	*q = append(*q, x.(priorityItem))
+}
+
+func (q *priorityQueue) Pop() any {
+	last := (*q)[len(*q)-1]
+	*q = (*q)[:len(*q)-1]
+	return last
+}
+
+func (q *priorityQueue) push(node int, priority float64) {
+	heap.Push(q, priorityItem{node, priority})
+}
+
+func (q *priorityQueue) pop() priorityItem {
+	return heap.Pop(q).(priorityItem)
+}
+
+// The result of a single source shortest path search. Only nodes whose distance is final are reported, which after an
+// early exit may be fewer than every reachable node.
+type ShortestPathTree[T any] struct {
+	root    Node[T]
+	ig      indexedGraph[T]
+	dist    []float64
+	pred    []int // -1 for the root and unreached nodes
+	settled []bool
+}
+
+func newShortestPathTree[T any](ig indexedGraph[T], root int) ShortestPathTree[T] {
+	n := len(ig.nodes)
+	tree := ShortestPathTree[T]{
+		root:    ig.nodes[root],
+		ig:      ig,
+		dist:    make([]float64, n),
+		pred:    make([]int, n),
+		settled: make([]bool, n),
+	}
+	for node := range n {
+		tree.dist[node] = math.Inf(1)
+		tree.pred[node] = -1
+	}
+	tree.dist[root] = 0
+	return tree
+}
+
+func (t ShortestPathTree[T]) Root() Node[T] {
+	return t.root
+}
+
+func (t ShortestPathTree[T]) settledID(node Node[T]) (int, bool) {
+	id, ok := t.ig.id(node)
+	if !ok || !t.settled[id] {
+		return 0, false
+	}
+	return id, true
+}
+
+// Checks if a shortest path from the root to the node is known.
+func (t ShortestPathTree[T]) IsReachable(node Node[T]) bool {
+	_, ok := t.settledID(node)
+	return ok
+}
+
+// Returns the length of the shortest path from the root to the node. Reports false if the node is not reachable.
+func (t ShortestPathTree[T]) DistanceTo(node Node[T]) (float64, bool) {
+	id, ok := t.settledID(node)
+	if !ok {
+		return math.Inf(1), false
+	}
+	return t.dist[id], true
+}
+
+// Returns the node right before the given one on its shortest path from the root. Reports false for the root itself
+// and for nodes that are not reachable.
+func (t ShortestPathTree[T]) Predecessor(node Node[T]) (Node[T], bool) {
+	id, ok := t.settledID(node)
+	if !ok || t.pred[id] == -1 {
+		return nil, false
+	}
+	return t.ig.nodes[t.pred[id]], true
+}
+
+// Returns the shortest path from the root to the node, both ends included. Reports false if the node is not reachable.
+func (t ShortestPathTree[T]) PathTo(node Node[T]) ([]Node[T], bool) {
+	id, ok := t.settledID(node)
+	if !ok {
+		return nil, false
+	}
+	return t.ig.toNodes(t.pathTo(id)), true
+}
+
+func (t ShortestPathTree[T]) pathTo(id int) []int {
+	path := []int{}
+	for curr := id; curr != -1; curr = t.pred[curr] {
+		path = append(path, curr)
+	}
+	slices.Reverse(path)
+	return path
+}
+
+// Returns every node with a known shortest path in the order they were added to the graph, the root included.
+func (t ShortestPathTree[T]) ReachableNodes() []Node[T] {
+	reachable := []Node[T]{}
+	for id, settled := range t.settled {
+		if settled {
+			reachable = append(reachable, t.ig.nodes[id])
+		}
+	}
+	return reachable
+}
+
+// Validates the inputs of a Dijkstras search and returns the ids of the given nodes.
+func (g Graph[T]) prepareDijkstras(ig indexedGraph[T], nodes ...Node[T]) ([]int, error) {
+	ids := []int{}
+	for _, node := range nodes {
+		id, ok := ig.id(node)
+		if !ok {
+			return nil, &NodeNotFoundError[T]{Node: node}
+		}
+		ids = append(ids, id)
+	}
+	if edge, ok := g.findNegativeEdge(); ok {
+		return nil, &NegativeWeightError[T]{Edge: edge}
+	}
+	return ids, nil
+}
+
+// Runs Dijkstras from the root and returns the shortest path to every reachable node. Fails with a NodeNotFoundError
+// if the root is not part of this graph and with a NegativeWeightError holding the first negative edge if there is one.
+func (g Graph[T]) DijkstrasTree(root Node[T]) (ShortestPathTree[T], error) {
+	ig := g.toIndexed()
+	ids, err := g.prepareDijkstras(ig, root)
+	if err != nil {
+		return ShortestPathTree[T]{}, err
+	}
+	return ig.dijkstras(ids[0], -1, nil), nil
+}
+
+// Runs Dijkstras from the root but stops as soon as the shortest path to the target is known. The returned tree holds
+// every node settled up to that point, the target included if it is reachable. Fails like DijkstrasTree, and also if
+// the target is not part of this graph.
+func (g Graph[T]) DijkstrasTo(root Node[T], target Node[T]) (ShortestPathTree[T], error) {
+	ig := g.toIndexed()
+	ids, err := g.prepareDijkstras(ig, root, target)
+	if err != nil {
+		return ShortestPathTree[T]{}, err
+	}
+	return ig.dijkstras(ids[0], ids[1], nil), nil
+}
+
+// Settles nodes in order of their distance from the root until the queue runs dry or the target, if not -1, is
+// settled. Edges are only followed if allowed, which may be nil to follow all of them. Edge weights must not be
+// negative.
+func (ig indexedGraph[T]) dijkstras(root int, target int, allowed func(from int, edge indexedEdge) bool) ShortestPathTree[T] {
+	tree := newShortestPathTree(ig, root)
+	queue := &priorityQueue{}
+	queue.push(root, 0)
+	for queue.Len() > 0 {
+		curr := queue.pop().node
+		if tree.settled[curr] {
+			continue
+		}
+		tree.settled[curr] = true
+		if curr == target {
+			break
+		}
+		for _, edge := range ig.out[curr] {
+			if allowed != nil && !allowed(curr, edge) {
+				continue
+			}
+			newDist := tree.dist[curr] + edge.weight
+			if newDist < tree.dist[edge.to] {
+				tree.dist[edge.to] = newDist
+				tree.pred[edge.to] = curr
+				queue.push(edge.to, newDist)
+			}
+		}
+	}
+	return tree
+}
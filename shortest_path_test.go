@@ -0,0 +1,83 @@
+package graph_test
+
+import (
+	"graph"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+/*
+1 ──10──► 2 ──1──► 4
+│         ▲
+1         1
+▼         │
+3 ────────┘
+*/
+func detour() graph.Graph[int] {
+	return graph.CreateDirected[int]().
+		AddEdge(NumberNode{1}, NumberNode{2}, 10).
+		AddEdge(NumberNode{1}, NumberNode{3}, 1).
+		AddEdge(NumberNode{3}, NumberNode{2}, 1).
+		AddEdge(NumberNode{2}, NumberNode{4}, 1).
+		AddNode(NumberNode{5})
+}
+
+func TestDijkstrasTree(t *testing.T) {
+	tree, err := detour().DijkstrasTree(NumberNode{1})
+	assert.NoError(t, err)
+	assert.Equal(t, graph.Node[int](NumberNode{1}), tree.Root())
+
+	// The cheaper detour over 3 wins over the direct edge.
+	dist, ok := tree.DistanceTo(NumberNode{4})
+	assert.True(t, ok)
+	assert.Equal(t, 3.0, dist)
+	path, ok := tree.PathTo(NumberNode{4})
+	assert.True(t, ok)
+	assert.Equal(t, []graph.Node[int]{NumberNode{1}, NumberNode{3}, NumberNode{2}, NumberNode{4}}, path)
+	pred, ok := tree.Predecessor(NumberNode{2})
+	assert.True(t, ok)
+	assert.Equal(t, graph.Node[int](NumberNode{3}), pred)
+	_, ok = tree.Predecessor(NumberNode{1})
+	assert.False(t, ok)
+
+	// Unreachable nodes have no distance or path.
+	assert.False(t, tree.IsReachable(NumberNode{5}))
+	dist, ok = tree.DistanceTo(NumberNode{5})
+	assert.False(t, ok)
+	assert.True(t, math.IsInf(dist, 1))
+	_, ok = tree.PathTo(NumberNode{42})
+	assert.False(t, ok)
+	assert.Equal(t, []graph.Node[int]{NumberNode{1}, NumberNode{2}, NumberNode{3}, NumberNode{4}}, tree.ReachableNodes())
+
+	paths := detour().Dijkstras(NumberNode{1})
+	assert.Len(t, paths, 4)
+	assert.Equal(t, path, paths[4])
+}
+
+func TestDijkstrasUndirected(t *testing.T) {
+	g := graph.CreateUndirected[int]().
+		AddEdge(NumberNode{2}, NumberNode{1}, 4).
+		AddEdge(NumberNode{3}, NumberNode{2}, 1).
+		AddEdge(NumberNode{1}, NumberNode{3}, 2)
+	tree, err := g.DijkstrasTree(NumberNode{1})
+	assert.NoError(t, err)
+	dist, _ := tree.DistanceTo(NumberNode{2})
+	assert.Equal(t, 3.0, dist)
+}
+
+func TestDijkstrasTo(t *testing.T) {
+	tree, err := detour().DijkstrasTo(NumberNode{1}, NumberNode{3})
+	assert.NoError(t, err)
+	dist, ok := tree.DistanceTo(NumberNode{3})
+	assert.True(t, ok)
+	assert.Equal(t, 1.0, dist)
+	// The search stopped before the far side of the graph was settled.
+	assert.False(t, tree.IsReachable(NumberNode{4}))
+
+	_, err = detour().DijkstrasTo(NumberNode{1}, NumberNode{42})
+	assert.ErrorIs(t, err, graph.ErrNodeNotFound)
+	_, err = detour().AddEdge(NumberNode{4}, NumberNode{5}, -1).DijkstrasTree(NumberNode{1})
+	assert.ErrorIs(t, err, graph.ErrNegativeWeight)
+}
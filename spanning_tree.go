@@ -0,0 +1,92 @@
+package graph
+
+import (
+	"cmp"
+	"slices"
+)
+
+// Computes a minimum spanning forest of this undirected graph with Kruskal's algorithm. The forest is returned as a new
+// undirected graph holding every node of this graph and the chosen edges, together with their total weight. Edges of
+// equal weight are considered in the order they were added. Fails with ErrDirectedGraph on a directed graph.
+func (g Graph[T]) MinimumSpanningForest() (Graph[T], float64, error) {
+	return g.kruskal(func(a, b Edge[T]) int {
+		return cmp.Compare(a.weight, b.weight)
+	})
+}
+
+// Computes a maximum spanning forest of this undirected graph, i.e. MinimumSpanningForest with the heaviest edges
+// preferred. Fails with ErrDirectedGraph on a directed graph.
+func (g Graph[T]) MaximumSpanningForest() (Graph[T], float64, error) {
+	return g.kruskal(func(a, b Edge[T]) int {
+		return cmp.Compare(b.weight, a.weight)
+	})
+}
+
+// Adds edges in the given order whenever they join two trees of the forest built so far.
+func (g Graph[T]) kruskal(order func(a, b Edge[T]) int) (Graph[T], float64, error) {
+	if g.directed {
+		return Graph[T]{}, 0, ErrDirectedGraph
+	}
+	forest := CreateUndirected[T]()
+	components := CreateDisjointSet[T]()
+	for _, node := range g.GetNodes() {
+		forest = forest.AddNode(node)
+		components.Add(node)
+	}
+	edges := g.GetEdges()
+	slices.SortStableFunc(edges, order)
+	total := 0.0
+	for _, edge := range edges {
+		if components.Union(edge.u, edge.v) {
+			forest = forest.AddEdge(edge.u, edge.v, edge.weight)
+			total += edge.weight
+		}
+	}
+	return forest, total, nil
+}
+
+// Computes a minimum spanning tree of the component containing the root with Prim's algorithm. The tree is returned as
+// a new undirected graph holding the nodes reachable from the root and the chosen edges, in the order they were
+// chosen, together with their total weight. Fails with a NodeNotFoundError if the root is not part of this graph and
+// with ErrDirectedGraph on a directed graph.
+func (g Graph[T]) PrimMST(root Node[T]) (Graph[T], float64, error) {
+	if g.directed {
+		return Graph[T]{}, 0, ErrDirectedGraph
+	}
+	ig := g.toIndexed()
+	rootID, ok := ig.id(root)
+	if !ok {
+		return Graph[T]{}, 0, &NodeNotFoundError[T]{Node: root}
+	}
+	// The cheapest known edge connecting each node to the tree, as the weight and the tree node it starts from.
+	cheapest := map[int]float64{rootID: 0}
+	parent := map[int]int{rootID: -1}
+	inTree := make([]bool, len(ig.nodes))
+	queue := &priorityQueue{}
+	queue.push(rootID, 0)
+	tree := CreateUndirected[T]().AddNode(root)
+	total := 0.0
+	for queue.Len() > 0 {
+		item := queue.pop()
+		curr := item.node
+		if inTree[curr] || item.priority > cheapest[curr] {
+			continue
+		}
+		inTree[curr] = true
+		if parent[curr] != -1 {
+			tree = tree.AddEdge(ig.nodes[parent[curr]], ig.nodes[curr], item.priority)
+			total += item.priority
+		}
+		for _, edge := range ig.out[curr] {
+			if inTree[edge.to] {
+				continue
+			}
+			if weight, ok := cheapest[edge.to]; !ok || edge.weight < weight {
+				cheapest[edge.to] = edge.weight
+				parent[edge.to] = curr
+				queue.push(edge.to, edge.weight)
+			}
+		}
+	}
+	return tree, total, nil
+}
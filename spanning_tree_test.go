@@ -0,0 +1,95 @@
+package graph_test
+
+import (
+	"graph"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+/*
+1 ──4── 2       5 ──1── 6
+│ ╲     │
+1   3   2
+│     ╲ │
+3 ──5── 4
+*/
+func spanning() graph.Graph[int] {
+	return graph.CreateUndirected[int]().
+		AddEdge(NumberNode{1}, NumberNode{2}, 4).
+		AddEdge(NumberNode{1}, NumberNode{3}, 1).
+		AddEdge(NumberNode{1}, NumberNode{4}, 3).
+		AddEdge(NumberNode{2}, NumberNode{4}, 2).
+		AddEdge(NumberNode{3}, NumberNode{4}, 5).
+		AddEdge(NumberNode{5}, NumberNode{6}, 1).
+		AddNode(NumberNode{7})
+}
+
+func TestMinimumSpanningForest(t *testing.T) {
+	forest, total, err := spanning().MinimumSpanningForest()
+	assert.NoError(t, err)
+	assert.Equal(t, 7.0, total)
+	assert.Equal(t, 7, forest.GetNumberOfNodes())
+	assert.Equal(t, []graph.Edge[int]{
+		graph.CreateEdge[int](NumberNode{1}, NumberNode{3}, 1),
+		graph.CreateEdge[int](NumberNode{5}, NumberNode{6}, 1),
+		graph.CreateEdge[int](NumberNode{2}, NumberNode{4}, 2),
+		graph.CreateEdge[int](NumberNode{1}, NumberNode{4}, 3),
+	}, forest.GetEdges())
+	assert.False(t, forest.IsDirectedGraph())
+	assert.Len(t, forest.ConnectedComponents(), 3)
+
+	forest, total, err = spanning().MaximumSpanningForest()
+	assert.NoError(t, err)
+	assert.Equal(t, 13.0, total)
+	assert.Equal(t, 4, forest.GetNumberOfEdges())
+	assert.True(t, forest.ContainsNode(NumberNode{7}))
+
+	_, _, err = detour().MinimumSpanningForest()
+	assert.ErrorIs(t, err, graph.ErrDirectedGraph)
+}
+
+func TestPrimMST(t *testing.T) {
+	tree, total, err := spanning().PrimMST(NumberNode{1})
+	assert.NoError(t, err)
+	assert.Equal(t, 6.0, total)
+	assert.Equal(t, []graph.Node[int]{NumberNode{1}, NumberNode{3}, NumberNode{4}, NumberNode{2}}, tree.GetNodes())
+	assert.Equal(t, []graph.Edge[int]{
+		graph.CreateEdge[int](NumberNode{1}, NumberNode{3}, 1),
+		graph.CreateEdge[int](NumberNode{1}, NumberNode{4}, 3),
+		graph.CreateEdge[int](NumberNode{4}, NumberNode{2}, 2),
+	}, tree.GetEdges())
+
+	tree, total, err = spanning().PrimMST(NumberNode{7})
+	assert.NoError(t, err)
+	assert.Equal(t, 0.0, total)
+	assert.Equal(t, 1, tree.GetNumberOfNodes())
+
+	_, _, err = spanning().PrimMST(NumberNode{42})
+	assert.ErrorIs(t, err, graph.ErrNodeNotFound)
+	_, _, err = detour().PrimMST(NumberNode{1})
+	assert.ErrorIs(t, err, graph.ErrDirectedGraph)
+}
+
+func TestPrimMatchesKruskal(t *testing.T) {
+	random := rand.New(rand.NewSource(3))
+	for range 20 {
+		g := graph.CreateUndirected[int]().AddNode(NumberNode{0})
+		for node := 1; node < 15; node++ {
+			// Keeps the graph connected so a single tree spans all of it.
+			g = g.AddEdge(NumberNode{random.Intn(node)}, NumberNode{node}, float64(random.Intn(20)-5))
+		}
+		for range 20 {
+			g = g.AddEdge(NumberNode{random.Intn(15)}, NumberNode{random.Intn(15)}, float64(random.Intn(20)-5))
+		}
+		forest, kruskal, err := g.MinimumSpanningForest()
+		assert.NoError(t, err)
+		tree, prim, err := g.PrimMST(NumberNode{0})
+		assert.NoError(t, err)
+		assert.Equal(t, kruskal, prim)
+		assert.Equal(t, 14, forest.GetNumberOfEdges())
+		assert.Equal(t, 14, tree.GetNumberOfEdges())
+		assert.True(t, tree.IsConnected())
+	}
+}
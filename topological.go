@@ -0,0 +1,142 @@
+package graph
+
+import (
+	"container/heap"
+	"iter"
+	"math/big"
+)
+
+// Checks that this graph can be sorted topologically, reporting the same errors as TopologicalSorts.
+func (g Graph[T]) checkDAG() error {
+	if !g.directed {
+		return ErrUndirectedGraph
+	}
+	if cycle := g.findDirectedCycle(); cycle != nil {
+		return &NotDAGError[T]{Cycle: cycle}
+	}
+	return nil
+}
+
+// Number of edges leading to each node, parallel edges included.
+func (ig indexedGraph[T]) inDegrees() []int {
+	indegrees := make([]int, len(ig.nodes))
+	for node := range ig.nodes {
+		indegrees[node] = len(ig.in[node])
+	}
+	return indegrees
+}
+
+// Computes a single topological sort with Kahn's algorithm. Whenever several nodes are ready the smallest one according
+// to Node.Compare goes first, so the result is deterministic. Fails like TopologicalSorts.
+func (g Graph[T]) TopologicalSort() ([]Node[T], error) {
+	if err := g.checkDAG(); err != nil {
+		return nil, err
+	}
+	ig := g.toIndexed()
+	indegrees := ig.inDegrees()
+	ready := &nodeMinHeap[T]{heap: []Node[T]{}}
+	for node, indegree := range indegrees {
+		if indegree == 0 {
+			ready.heap = append(ready.heap, ig.nodes[node])
+		}
+	}
+	heap.Init(ready)
+	ordering := make([]Node[T], 0, len(ig.nodes))
+	for ready.Len() > 0 {
+		next := heap.Pop(ready).(Node[T])
+		ordering = append(ordering, next)
+		id, _ := ig.id(next)
+		for _, edge := range ig.out[id] {
+			indegrees[edge.to]--
+			if indegrees[edge.to] == 0 {
+				heap.Push(ready, ig.nodes[edge.to])
+			}
+		}
+	}
+	return ordering, nil
+}
+
+// Lazily enumerates every topological sort of this graph. Sorts are produced by backtracking over the ready nodes in
+// the order they were added, so stopping the iteration early skips the remaining work entirely. Fails like
+// TopologicalSorts before anything is enumerated.
+func (g Graph[T]) AllTopologicalSorts() (iter.Seq[[]Node[T]], error) {
+	if err := g.checkDAG(); err != nil {
+		return nil, err
+	}
+	return func(yield func([]Node[T]) bool) {
+		ig := g.toIndexed()
+		n := len(ig.nodes)
+		indegrees := ig.inDegrees()
+		placed := make([]bool, n)
+		ordering := make([]int, 0, n)
+		var backtrack func() bool
+		backtrack = func() bool {
+			if len(ordering) == n {
+				return yield(ig.toNodes(ordering))
+			}
+			for node := range n {
+				if placed[node] || indegrees[node] != 0 {
+					continue
+				}
+				placed[node] = true
+				ordering = append(ordering, node)
+				for _, edge := range ig.out[node] {
+					indegrees[edge.to]--
+				}
+				if !backtrack() {
+					return false
+				}
+				for _, edge := range ig.out[node] {
+					indegrees[edge.to]++
+				}
+				ordering = ordering[:len(ordering)-1]
+				placed[node] = false
+			}
+			return true
+		}
+		backtrack()
+	}, nil
+}
+
+// Counts the topological sorts of this graph without enumerating them. Counts are memoized per set of already placed
+// nodes, so the work depends on how many such sets are reachable rather than on the number of sorts, which is what
+// makes graphs with long chains cheap. Wide graphs with many independent nodes still need exponential work. Fails like
+// TopologicalSorts.
+func (g Graph[T]) CountTopologicalSorts() (*big.Int, error) {
+	if err := g.checkDAG(); err != nil {
+		return nil, err
+	}
+	ig := g.toIndexed()
+	n := len(ig.nodes)
+	indegrees := ig.inDegrees()
+	placed := make([]byte, (n+7)/8)
+	memo := map[string]*big.Int{}
+	var count func(remaining int) *big.Int
+	count = func(remaining int) *big.Int {
+		if remaining == 0 {
+			return big.NewInt(1)
+		}
+		key := string(placed)
+		if cached, ok := memo[key]; ok {
+			return cached
+		}
+		total := new(big.Int)
+		for node := range n {
+			if placed[node/8]&(1<<(node%8)) != 0 || indegrees[node] != 0 {
+				continue
+			}
+			placed[node/8] |= 1 << (node % 8)
+			for _, edge := range ig.out[node] {
+				indegrees[edge.to]--
+			}
+			total.Add(total, count(remaining-1))
+			for _, edge := range ig.out[node] {
+				indegrees[edge.to]++
+			}
+			placed[node/8] &^= 1 << (node % 8)
+		}
+		memo[key] = total
+		return total
+	}
+	return count(n), nil
+}
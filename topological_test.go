@@ -0,0 +1,74 @@
+package graph_test
+
+import (
+	"graph"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTopologicalSort(t *testing.T) {
+	// Ties between ready nodes are broken by Compare, not by the order nodes were added in.
+	g := graph.CreateDirected[int]().
+		AddNode(NumberNode{5}).
+		AddEdge(NumberNode{3}, NumberNode{1}, 0).
+		AddEdge(NumberNode{3}, NumberNode{1}, 0).
+		AddEdge(NumberNode{4}, NumberNode{2}, 0).
+		AddEdge(NumberNode{1}, NumberNode{2}, 0)
+	ordering, err := g.TopologicalSort()
+	assert.NoError(t, err)
+	assert.Equal(t, []graph.Node[int]{NumberNode{3}, NumberNode{1}, NumberNode{4}, NumberNode{2}, NumberNode{5}}, ordering)
+
+	_, err = abc().TopologicalSort()
+	assert.ErrorIs(t, err, graph.ErrNotDAG)
+	_, err = graph.CreateUndirected[int]().TopologicalSort()
+	assert.ErrorIs(t, err, graph.ErrUndirectedGraph)
+}
+
+func TestAllTopologicalSorts(t *testing.T) {
+	sorts, err := abcNoEdges().AllTopologicalSorts()
+	assert.NoError(t, err)
+	// Stopping early only produces the orderings that were asked for.
+	first := [][]graph.Node[string]{}
+	for ordering := range sorts {
+		first = append(first, ordering)
+		if len(first) == 2 {
+			break
+		}
+	}
+	assert.Equal(t, [][]graph.Node[string]{
+		{StringNode{"A"}, StringNode{"B"}, StringNode{"C"}},
+		{StringNode{"A"}, StringNode{"C"}, StringNode{"B"}},
+	}, first)
+
+	// Parallel edges must be released together.
+	parallel := graph.CreateDirected[int]().
+		AddEdge(NumberNode{1}, NumberNode{2}, 0).
+		AddEdge(NumberNode{1}, NumberNode{2}, 0)
+	all, err := parallel.TopologicalSorts()
+	assert.NoError(t, err)
+	assert.Equal(t, [][]graph.Node[int]{{NumberNode{1}, NumberNode{2}}}, all)
+
+	_, err = abc().AllTopologicalSorts()
+	assert.ErrorIs(t, err, graph.ErrNotDAG)
+}
+
+func TestCountTopologicalSorts(t *testing.T) {
+	count, err := abcNoEdges().CountTopologicalSorts()
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(6), count)
+
+	// Two independent chains of 30 nodes interleave in 60 choose 30 ways, far too many to enumerate.
+	chains := graph.CreateDirected[int]()
+	for i := range 29 {
+		chains = chains.AddEdge(NumberNode{i}, NumberNode{i + 1}, 0)
+		chains = chains.AddEdge(NumberNode{100 + i}, NumberNode{100 + i + 1}, 0)
+	}
+	count, err = chains.CountTopologicalSorts()
+	assert.NoError(t, err)
+	assert.Equal(t, new(big.Int).Binomial(60, 30), count)
+
+	_, err = abc().CountTopologicalSorts()
+	assert.ErrorIs(t, err, graph.ErrNotDAG)
+}
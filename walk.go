@@ -0,0 +1,96 @@
+package graph
+
+import "slices"
+
+// Tells Walk how to carry on after a Visitor hook.
+type WalkAction int
+
+const (
+	// Carries on as usual.
+	WalkContinue WalkAction = iota
+	// Does not look past the current node or edge. Returned from OnDiscover the edges of the node are not explored,
+	// returned from OnEdge the edge is not followed. Has the same effect as WalkContinue when returned from OnFinish.
+	WalkSkipChildren
+	// Ends the walk right away, no further hooks are called.
+	WalkStop
+)
+
+// Hooks that Walk calls while it explores a graph. Every hook is optional, a nil hook acts as if it returned
+// WalkContinue.
+type Visitor[T any] struct {
+	// Called once for every node when it is reached, depth is the number of tree edges between it and the source.
+	OnDiscover func(node Node[T], depth int) WalkAction
+	// Called for every edge leading from a discovered node before it is followed, even if it leads to a node that was
+	// already discovered. In an undirected graph this includes the edge back to the node it was discovered from.
+	OnEdge func(edge Edge[T]) WalkAction
+	// Called once every edge of the node has been dealt with.
+	OnFinish func(node Node[T]) WalkAction
+}
+
+// Walks this graph depth first from the source, visiting neighbors in the order of the graph comparator like DFS, and
+// calls the hooks of the visitor along the way. The hooks decide how far the walk goes, so bounded searches, depth
+// limits or looking for the first match only ever explore what they need to. Runs iteratively. Fails with a
+// NodeNotFoundError if the source is not part of this graph.
+func (g Graph[T]) Walk(source Node[T], visitor Visitor[T]) error {
+	if !g.ContainsNode(source) {
+		return &NodeNotFoundError[T]{Node: source}
+	}
+	type frame struct {
+		node  Node[T]
+		depth int
+		edges []Edge[T]
+		next  int
+	}
+	visited := newNodeSet[T]()
+	stack := []frame{}
+	// Discovers the node and pushes it onto the stack. Reports false if the walk has to stop.
+	enter := func(node Node[T], depth int) bool {
+		visited.add(node)
+		action := WalkContinue
+		if visitor.OnDiscover != nil {
+			action = visitor.OnDiscover(node, depth)
+		}
+		if action == WalkStop {
+			return false
+		}
+		f := frame{node: node, depth: depth}
+		if action != WalkSkipChildren {
+			f.edges = g.FindEdgesThatLeadFrom(node)
+			slices.SortStableFunc(f.edges, func(a, b Edge[T]) int {
+				return a.v.Compare(b.v)
+			})
+		}
+		stack = append(stack, f)
+		return true
+	}
+	if !enter(source, 0) {
+		return nil
+	}
+	for len(stack) > 0 {
+		top := len(stack) - 1
+		if stack[top].next == len(stack[top].edges) {
+			node := stack[top].node
+			stack = stack[:top]
+			if visitor.OnFinish != nil && visitor.OnFinish(node) == WalkStop {
+				return nil
+			}
+			continue
+		}
+		edge := stack[top].edges[stack[top].next]
+		stack[top].next++
+		action := WalkContinue
+		if visitor.OnEdge != nil {
+			action = visitor.OnEdge(edge)
+		}
+		if action == WalkStop {
+			return nil
+		}
+		if action == WalkSkipChildren || visited.has(edge.v) {
+			continue
+		}
+		if !enter(edge.v, stack[top].depth+1) {
+			return nil
+		}
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package graph_test
+
+import (
+	"fmt"
+	"graph"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Records every hook call as a short string.
+func recorder(events *[]string) graph.Visitor[int] {
+	return graph.Visitor[int]{
+		OnDiscover: func(node graph.Node[int], depth int) graph.WalkAction {
+			*events = append(*events, fmt.Sprint("discover ", node.Val()))
+			return graph.WalkContinue
+		},
+		OnEdge: func(edge graph.Edge[int]) graph.WalkAction {
+			*events = append(*events, fmt.Sprintf("edge %d->%d", edge.U().Val(), edge.V().Val()))
+			return graph.WalkContinue
+		},
+		OnFinish: func(node graph.Node[int]) graph.WalkAction {
+			*events = append(*events, fmt.Sprint("finish ", node.Val()))
+			return graph.WalkContinue
+		},
+	}
+}
+
+func TestWalk(t *testing.T) {
+	events := []string{}
+	assert.NoError(t, forestGraph().Walk(NumberNode{4}, recorder(&events)))
+	assert.Equal(t, []string{
+		"discover 4", "edge 4->4", "edge 4->5", "discover 5", "finish 5", "finish 4",
+	}, events)
+
+	// Without hooks the walk simply visits everything reachable.
+	assert.NoError(t, forestGraph().Walk(NumberNode{1}, graph.Visitor[int]{}))
+	assert.ErrorIs(t, forestGraph().Walk(NumberNode{42}, graph.Visitor[int]{}), graph.ErrNodeNotFound)
+}
+
+func TestWalkUndirected(t *testing.T) {
+	g := graph.CreateUndirected[int]().
+		AddEdge(NumberNode{1}, NumberNode{2}, 0).
+		AddEdge(NumberNode{3}, NumberNode{2}, 0)
+	events := []string{}
+	assert.NoError(t, g.Walk(NumberNode{1}, recorder(&events)))
+	// Every edge leads both ways, so OnEdge also sees the tree edge leading back to the parent.
+	assert.Equal(t, []string{
+		"discover 1", "edge 1->2", "discover 2", "edge 2->1", "edge 2->3", "discover 3", "edge 3->2",
+		"finish 3", "finish 2", "finish 1",
+	}, events)
+}
+
+func TestWalkDepthLimit(t *testing.T) {
+	g := graph.CreateDirected[int]().
+		AddEdge(NumberNode{1}, NumberNode{2}, 0).
+		AddEdge(NumberNode{2}, NumberNode{3}, 0).
+		AddEdge(NumberNode{3}, NumberNode{4}, 0).
+		AddEdge(NumberNode{1}, NumberNode{5}, 0)
+	visited := []int{}
+	finished := []int{}
+	err := g.Walk(NumberNode{1}, graph.Visitor[int]{
+		OnDiscover: func(node graph.Node[int], depth int) graph.WalkAction {
+			visited = append(visited, node.Val())
+			if depth == 1 {
+				return graph.WalkSkipChildren
+			}
+			return graph.WalkContinue
+		},
+		OnFinish: func(node graph.Node[int]) graph.WalkAction {
+			finished = append(finished, node.Val())
+			return graph.WalkContinue
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 5}, visited)
+	// Skipped nodes are still finished.
+	assert.Equal(t, []int{2, 5, 1}, finished)
+}
+
+func TestWalkPruneEdgesAndStop(t *testing.T) {
+	// Never following edges into 2 keeps 3 out of reach as well.
+	visited := []int{}
+	err := forestGraph().Walk(NumberNode{1}, graph.Visitor[int]{
+		OnDiscover: func(node graph.Node[int], depth int) graph.WalkAction {
+			visited = append(visited, node.Val())
+			return graph.WalkContinue
+		},
+		OnEdge: func(edge graph.Edge[int]) graph.WalkAction {
+			if edge.V().Val() == 2 {
+				return graph.WalkSkipChildren
+			}
+			return graph.WalkContinue
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 4, 5}, visited)
+
+	// Finding the first node that matches stops right there.
+	var found graph.Node[int]
+	events := []string{}
+	visitor := recorder(&events)
+	onDiscover := visitor.OnDiscover
+	visitor.OnDiscover = func(node graph.Node[int], depth int) graph.WalkAction {
+		onDiscover(node, depth)
+		if node.Val() == 3 {
+			found = node
+			return graph.WalkStop
+		}
+		return graph.WalkContinue
+	}
+	assert.NoError(t, forestGraph().Walk(NumberNode{1}, visitor))
+	assert.Equal(t, graph.Node[int](NumberNode{3}), found)
+	assert.Equal(t, []string{"discover 1", "edge 1->2", "discover 2", "edge 2->3", "discover 3"}, events)
+}